@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// importBatch runs item writes inside a transaction, committing every size rows.
+// A size of 0 runs the whole import in a single transaction
+type importBatch struct {
+	db        *sqlx.DB
+	tx        *sqlx.Tx
+	size      int
+	number    int
+	rows      int
+	total     int
+	firstLine int
+	lastLine  int
+	// onCommit is called with the batch's last line after each commit
+	onCommit func(lastLine int)
+}
+
+func newImportBatch(db *sqlx.DB, size int) *importBatch {
+	return &importBatch{
+		db:   db,
+		size: size,
+	}
+}
+
+// QueryRowx queries inside the open transaction if there is one, so rows written earlier in the batch are visible
+func (b *importBatch) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	if b.tx != nil {
+		return b.tx.QueryRowx(query, args...)
+	}
+	return b.db.QueryRowx(query, args...)
+}
+
+// Exec runs a named query for the item found on line, starting a new transaction if needed
+func (b *importBatch) Exec(line int, query string, arg interface{}) error {
+	if err := b.begin(line); err != nil {
+		return err
+	}
+	b.lastLine = line
+
+	_, err := b.tx.NamedExec(query, arg)
+	if err != nil {
+		return err
+	}
+	return b.written(1)
+}
+
+// ExecBulk runs a fully rendered statement that writes rows items found on lines firstLine to lastLine
+func (b *importBatch) ExecBulk(firstLine int, lastLine int, query string, rows int) error {
+	if err := b.begin(firstLine); err != nil {
+		return err
+	}
+	b.lastLine = lastLine
+
+	_, err := b.tx.Exec(query)
+	if err != nil {
+		return err
+	}
+	return b.written(rows)
+}
+
+func (b *importBatch) begin(line int) error {
+	if b.tx != nil {
+		return nil
+	}
+	tx, err := b.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin")
+	}
+	b.tx = tx
+	b.number++
+	b.rows = 0
+	b.firstLine = line
+	return nil
+}
+
+// written counts rows against the batch size, committing once it is reached
+func (b *importBatch) written(rows int) error {
+	b.rows += rows
+	if b.size > 0 && b.rows >= b.size {
+		return b.Commit()
+	}
+	return nil
+}
+
+// Commit commits the open transaction, if any
+func (b *importBatch) Commit() error {
+	if b.tx == nil {
+		return nil
+	}
+	err := b.tx.Commit()
+	b.tx = nil
+	if err != nil {
+		return errors.Wrapf(err, "commit batch %d (lines %d-%d)", b.number, b.firstLine, b.lastLine)
+	}
+	b.total += b.rows
+	log.Debug().Msgf("committed batch %d (%d rows, lines %d-%d)", b.number, b.rows, b.firstLine, b.lastLine)
+	if b.onCommit != nil {
+		b.onCommit(b.lastLine)
+	}
+	return nil
+}
+
+// Rollback discards the open transaction and describes which batch failed and why
+func (b *importBatch) Rollback(cause error) error {
+	if b.tx == nil {
+		return cause
+	}
+	err := b.tx.Rollback()
+	b.tx = nil
+	if err != nil {
+		log.Warn().Err(err).Int("batch", b.number).Msg("rollback")
+	}
+	msg := fmt.Sprintf("batch %d (lines %d-%d) rolled back, %d rows in %d earlier batches were committed", b.number, b.firstLine, b.lastLine, b.total, b.number-1)
+	return errors.Wrap(cause, msg)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/eqitem/item"
+)
+
+// bulkPacketLimit caps statement size below the mysql driver's default max packet
+const bulkPacketLimit = 16 << 20
+
+// bulkInserter buffers items into multi-row INSERT statements no larger than maxPacket
+type bulkInserter struct {
+	schema    *item.Schema
+	maxPacket int
+	prefix    string
+	buf       strings.Builder
+	rows      int
+	firstLine int
+	lastLine  int
+	flush     func(firstLine int, lastLine int, query string, rows int) error
+}
+
+func newBulkInserter(schema *item.Schema, maxPacket int, flush func(firstLine int, lastLine int, query string, rows int) error) *bulkInserter {
+	if maxPacket > bulkPacketLimit {
+		maxPacket = bulkPacketLimit
+	}
+	//leave room for packet framing
+	maxPacket -= 1024
+	return &bulkInserter{
+		schema:    schema,
+		maxPacket: maxPacket,
+		prefix:    "INSERT INTO items " + new(item.EQEmuItem).InsertColumns(schema) + " VALUES\n",
+		flush:     flush,
+	}
+}
+
+// Add appends item to the pending statement, flushing first if it would grow past maxPacket
+func (bi *bulkInserter) Add(line int, item *item.EQEmuItem) error {
+	values, err := item.InsertValues(bi.schema)
+	if err != nil {
+		return errors.Wrapf(err, "values %d", item.ID)
+	}
+	if bi.rows > 0 && bi.buf.Len()+len(values)+2 > bi.maxPacket {
+		if err = bi.Flush(); err != nil {
+			return err
+		}
+	}
+	if bi.rows == 0 {
+		bi.buf.WriteString(bi.prefix)
+		bi.firstLine = line
+	} else {
+		bi.buf.WriteString(",\n")
+	}
+	bi.buf.WriteString(values)
+	bi.rows++
+	bi.lastLine = line
+	return nil
+}
+
+// Discard drops any pending rows, returning how many there were
+func (bi *bulkInserter) Discard() int {
+	rows := bi.rows
+	bi.buf.Reset()
+	bi.rows = 0
+	return rows
+}
+
+// Flush writes any pending rows as a single statement
+func (bi *bulkInserter) Flush() error {
+	if bi.rows == 0 {
+		return nil
+	}
+	bi.buf.WriteString(";")
+	err := bi.flush(bi.firstLine, bi.lastLine, bi.buf.String(), bi.rows)
+	bi.buf.Reset()
+	bi.rows = 0
+	if err != nil {
+		return errors.Wrapf(err, "bulk insert lines %d-%d", bi.firstLine, bi.lastLine)
+	}
+	return nil
+}
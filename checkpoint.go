@@ -0,0 +1,158 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// checkpoint records how far an import of an items file got. Every line up to Line is committed,
+// so --resume can skip them
+type checkpoint struct {
+	Path     string `json:"path"`
+	Checksum string `json:"checksum"`
+	Line     int    `json:"line"`
+	// ID is the id of the item on Line, as it appears in the items file
+	ID int64 `json:"id"`
+}
+
+// fileChecksum returns the sha256 of the file at path
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cp := &checkpoint{}
+	if err = json.Unmarshal(data, cp); err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+	return cp, nil
+}
+
+// save writes the checkpoint to a temporary file and renames it over path, so a crash never leaves half a checkpoint
+func (cp *checkpoint) save(path string) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encode")
+	}
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// checkpointWriter saves a checkpoint whenever a commit means every line up to a later one is committed.
+// Each writer commits on its own, so lines are only counted once the writer that wrote them has committed past them
+type checkpointWriter struct {
+	path string
+	mu   sync.Mutex
+	cp   checkpoint
+	// saved is the line of the last saved checkpoint
+	saved int
+	// committed is the last line each writer has committed
+	committed []int
+	// pending are reported lines, in input order, that are not known to be committed
+	pending []*pendingLine
+}
+
+// pendingLine is an item written by a writer, or an item that needed no write when isWrite is false
+type pendingLine struct {
+	writer  int
+	line    int
+	id      int64
+	isWrite bool
+}
+
+func newCheckpointWriter(path string, cp checkpoint, writers int) *checkpointWriter {
+	return &checkpointWriter{
+		path:      path,
+		cp:        cp,
+		saved:     cp.Line,
+		committed: make([]int, writers),
+	}
+}
+
+// reported records what happened to the item on line, in input order. A commit is often made while writing
+// the line that triggers it, before that line is reported, so the checkpoint is saved once it moves past a committed write.
+// Lines that needed no write are saved with the next one, rather than rewriting the file for each
+func (cw *checkpointWriter) reported(writer int, line int, id int64, isWrite bool) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.pending = append(cw.pending, &pendingLine{writer: writer, line: line, id: id, isWrite: isWrite})
+	if cw.advance() {
+		cw.save()
+	}
+}
+
+// commit records that writer committed every line it wrote up to line, and saves the checkpoint if it moved
+func (cw *checkpointWriter) commit(writer int, line int) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.committed[writer] = line
+	cw.advance()
+	cw.save()
+}
+
+// save writes the checkpoint if it has moved past the last one saved
+func (cw *checkpointWriter) save() {
+	if cw.cp.Line <= cw.saved {
+		return
+	}
+	if err := cw.cp.save(cw.path); err != nil {
+		log.Warn().Err(err).Str("path", cw.path).Msg("checkpoint")
+		return
+	}
+	cw.saved = cw.cp.Line
+}
+
+// advance moves the checkpoint past the pending lines that are committed or had nothing to commit,
+// returning true if it passed a committed write
+func (cw *checkpointWriter) advance() bool {
+	n := 0
+	isWrite := false
+	for ; n < len(cw.pending); n++ {
+		pl := cw.pending[n]
+		if pl.isWrite && pl.line > cw.committed[pl.writer] {
+			break
+		}
+		isWrite = isWrite || pl.isWrite
+	}
+	if n == 0 {
+		return false
+	}
+	last := cw.pending[n-1]
+	cw.cp.Line = last.line
+	cw.cp.ID = last.id
+	cw.pending = cw.pending[n:]
+	return isWrite
+}
+
+// remove deletes the checkpoint once the import has finished
+func (cw *checkpointWriter) remove() error {
+	err := os.Remove(cw.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/xackery/eqitem/item"
+)
+
+// openTestSQLite returns a sqlite database with an items table holding ids, skipping the test without cgo
+func openTestSQLite(t *testing.T, dir string, ids ...int64) (*sqlx.DB, *item.Schema) {
+	t.Helper()
+	db, err := sqlx.Open("sqlite3", filepath.Join(dir, "items.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec(new(item.EQEmuItem).CreateSQLiteQuery(nil)); err != nil {
+		db.Close()
+		if strings.Contains(err.Error(), "cgo") {
+			t.Skip(err)
+		}
+		t.Fatal(err)
+	}
+	schema, err := item.LoadSchema(db, item.DialectSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		it := &item.EQEmuItem{ID: id, Name: "old"}
+		if _, err = db.NamedExec(it.InsertQuery(schema), it); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db, schema
+}
+
+// TestCheckpointBulkInsertBeforeUpdates checks that a bulk insert buffered before updates that commit a batch is written before the checkpoint passes its line
+func TestCheckpointBulkInsertBeforeUpdates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eqitem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, schema := openTestSQLite(t, dir, 5, 6, 7, 8)
+	defer db.Close()
+
+	store := newSQLItemStore(db, schema, 2)
+	if err = store.enableBulk(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "eqitem.checkpoint")
+	checkpoints := newCheckpointWriter(path, checkpoint{Checksum: "test"}, 1)
+	store.batch.onCommit = func(lastLine int) {
+		checkpoints.commit(0, lastLine)
+	}
+	imp := newTestImporter(store)
+	imp.isUpdate = true
+	imp.isPreloaded = true
+	imp.checkpoints = checkpoints
+
+	//line 1 is a new item, lines 2-5 update existing ones, and line 6 fails the import
+	imp.start()
+	for line, id := range []int64{100, 5, 6, 7, 8} {
+		if err = imp.process(line+1, &item.EQEmuItem{ID: id, Name: "new"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = imp.abort(fmt.Errorf("line 6 failed"))
+	if err == nil {
+		t.Fatal("abort returned no error")
+	}
+
+	cp, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Line < 1 {
+		t.Fatalf("checkpoint at line %d, want it past line 1", cp.Line)
+	}
+	count := 0
+	if err = db.Get(&count, "SELECT COUNT(id) FROM items WHERE id = 100"); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("checkpoint is at line %d but item 100 from line 1 was not committed", cp.Line)
+	}
+}
+
+// TestCheckpointWriterSavesCommittedLines follows a batch of 3: each commit is made while writing the batch's last line, before it is reported
+func TestCheckpointWriterSavesCommittedLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eqitem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "eqitem.checkpoint")
+	cw := newCheckpointWriter(path, checkpoint{Checksum: "test"}, 1)
+
+	steps := []struct {
+		commit   int
+		line     int
+		isWrite  bool
+		wantLine int
+	}{
+		{line: 1, isWrite: true},
+		{line: 2, isWrite: true},
+		{commit: 3, line: 3, isWrite: true, wantLine: 3},
+		//lines that needed no write wait for the next committed write
+		{line: 4, wantLine: 3},
+		{line: 5, isWrite: true, wantLine: 3},
+		{line: 6, wantLine: 3},
+		{commit: 7, line: 7, isWrite: true, wantLine: 7},
+	}
+	for _, step := range steps {
+		if step.commit > 0 {
+			cw.commit(0, step.commit)
+		}
+		cw.reported(0, step.line, int64(step.line*10), step.isWrite)
+		cp, err := loadCheckpoint(path)
+		if step.wantLine == 0 {
+			if !os.IsNotExist(err) {
+				t.Errorf("line %d: checkpoint saved before any commit", step.line)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("line %d: %v", step.line, err)
+		}
+		if cp.Line != step.wantLine || cp.ID != int64(step.wantLine*10) {
+			t.Errorf("line %d: checkpoint at line %d (item %d), want line %d (item %d)", step.line, cp.Line, cp.ID, step.wantLine, step.wantLine*10)
+		}
+	}
+}
+
+// TestCheckpointImportSavesLastCommittedLine checks the saved checkpoint is the last line of the last committed batch
+func TestCheckpointImportSavesLastCommittedLine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eqitem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, schema := openTestSQLite(t, dir)
+	defer db.Close()
+
+	store := newSQLItemStore(db, schema, 3)
+	path := filepath.Join(dir, "eqitem.checkpoint")
+	checkpoints := newCheckpointWriter(path, checkpoint{Checksum: "test"}, 1)
+	store.batch.onCommit = func(lastLine int) {
+		checkpoints.commit(0, lastLine)
+	}
+	imp := newTestImporter(store)
+	imp.checkpoints = checkpoints
+
+	imp.start()
+	for id := int64(1); id <= 7; id++ {
+		if err = imp.process(int(id), &item.EQEmuItem{ID: id, Name: "new"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = imp.finish(); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Line != 6 || cp.ID != 6 {
+		t.Errorf("after 2 batches: checkpoint at line %d (item %d), want line 6 (item 6)", cp.Line, cp.ID)
+	}
+	if err = imp.commit(); err != nil {
+		t.Fatal(err)
+	}
+	if cp, err = loadCheckpoint(path); err != nil {
+		t.Fatal(err)
+	}
+	if cp.Line != 7 || cp.ID != 7 {
+		t.Errorf("after the last commit: checkpoint at line %d (item %d), want line 7 (item 7)", cp.Line, cp.ID)
+	}
+}
+
+// TestCheckpointBulkInsertBeforeSpells checks that statements written after the items, such as imported spells,
+// never commit a batch while bulk inserts are still buffered
+func TestCheckpointBulkInsertBeforeSpells(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eqitem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, schema := openTestSQLite(t, dir, 5)
+	defer db.Close()
+
+	store := newSQLItemStore(db, schema, 3)
+	if err = store.enableBulk(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "eqitem.checkpoint")
+	checkpoints := newCheckpointWriter(path, checkpoint{Checksum: "test"}, 1)
+	store.batch.onCommit = func(lastLine int) {
+		checkpoints.commit(0, lastLine)
+	}
+	imp := newTestImporter(store)
+	imp.isPreloaded = true
+	imp.checkpoints = checkpoints
+
+	//lines 1-2 are new items left buffered, then 3 statements fill the batch and the import fails
+	imp.start()
+	for line, id := range []int64{100, 101} {
+		if err = imp.process(line+1, &item.EQEmuItem{ID: id, Name: "new"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = imp.finish(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err = store.execStatement(2, "UPDATE items SET name = 'spell' WHERE id = 5"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = imp.abort(fmt.Errorf("spells failed")); err == nil {
+		t.Fatal("abort returned no error")
+	}
+
+	cp, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Line < 2 {
+		t.Fatalf("checkpoint at line %d, want it past line 2", cp.Line)
+	}
+	count := 0
+	if err = db.Get(&count, "SELECT COUNT(id) FROM items WHERE id IN (100, 101)"); err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("checkpoint is at line %d but only %d of the items on lines 1-2 were committed", cp.Line, count)
+	}
+}
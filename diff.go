@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xackery/eqitem/item"
+)
+
+// itemDiff is every change found for an item id
+type itemDiff struct {
+	ID      int64              `json:"id"`
+	Status  string             `json:"status"`
+	Changes []item.FieldChange `json:"changes,omitempty"`
+}
+
+// diffWriter writes a diff report in text, json or csv
+type diffWriter struct {
+	w      io.Writer
+	format string
+	csv    *csv.Writer
+	count  int
+}
+
+func newDiffWriter(w io.Writer, format string) (*diffWriter, error) {
+	dw := &diffWriter{
+		w:      w,
+		format: format,
+	}
+	switch format {
+	case "text":
+	case "json":
+		if _, err := fmt.Fprint(w, "["); err != nil {
+			return nil, err
+		}
+	case "csv":
+		dw.csv = csv.NewWriter(w)
+		if err := dw.csv.Write([]string{"id", "status", "field", "old", "new"}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown diff format: %s", format)
+	}
+	return dw, nil
+}
+
+// Write adds an item to the report
+func (dw *diffWriter) Write(diff *itemDiff) error {
+	dw.count++
+	switch dw.format {
+	case "json":
+		data, err := json.Marshal(diff)
+		if err != nil {
+			return err
+		}
+		sep := ",\n"
+		if dw.count == 1 {
+			sep = "\n"
+		}
+		_, err = fmt.Fprintf(dw.w, "%s%s", sep, data)
+		return err
+	case "csv":
+		id := strconv.FormatInt(diff.ID, 10)
+		if len(diff.Changes) == 0 {
+			return dw.csv.Write([]string{id, diff.Status, "", "", ""})
+		}
+		for _, change := range diff.Changes {
+			err := dw.csv.Write([]string{id, diff.Status, change.Field, change.Old, change.New})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(dw.w, "%d: %s\n", diff.ID, diff.Status); err != nil {
+		return err
+	}
+	for _, change := range diff.Changes {
+		if _, err := fmt.Fprintf(dw.w, "\t%s: %q -> %q\n", change.Field, change.Old, change.New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close finishes the report
+func (dw *diffWriter) Close() error {
+	switch dw.format {
+	case "json":
+		_, err := fmt.Fprint(dw.w, "\n]\n")
+		return err
+	case "csv":
+		dw.csv.Flush()
+		return dw.csv.Error()
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/eqitem/item"
+)
+
+// itemExporter writes items matching filter and where in an output format
+type itemExporter struct {
+	iw     item.Encoder
+	filter *idFilter
+	where  *whereExpr
+	count  int
+	// losses is the precision lost decoding the items written from a Reader
+	losses []*item.PrecisionLoss
+}
+
+// newItemExporter creates path, or uses stdout if path is -, and returns an exporter writing format to it
+func newItemExporter(path string, format string, filter *idFilter, where *whereExpr) (*itemExporter, io.Closer, error) {
+	var w io.WriteCloser = os.Stdout
+	if path != "-" {
+		out, err := os.Create(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		w = out
+	}
+	iw, err := item.NewEncoder(w, format)
+	if err != nil {
+		w.Close()
+		return nil, nil, err
+	}
+	return &itemExporter{iw: iw, filter: filter, where: where}, w, nil
+}
+
+func (ex *itemExporter) write(item *item.EQEmuItem) error {
+	if !ex.filter.match(item.ID) {
+		return nil
+	}
+	if ex.where != nil {
+		isMatch, err := ex.where.match(item)
+		if err != nil {
+			return errors.Wrapf(err, "where %d", item.ID)
+		}
+		if !isMatch {
+			return nil
+		}
+	}
+	if err := ex.iw.Encode(item); err != nil {
+		return errors.Wrapf(err, "write %d", item.ID)
+	}
+	ex.count++
+	return nil
+}
+
+// exportStore writes every stored item
+func (ex *itemExporter) exportStore(store ItemStore) error {
+	if err := store.Iterate(ex.write); err != nil {
+		return err
+	}
+	return ex.iw.Flush()
+}
+
+// exportReader writes every item read from ir, recording the precision lost on those that match the filters
+func (ex *itemExporter) exportReader(ir *item.Reader) error {
+	for ir.Next() {
+		count := ex.count
+		if err := ex.write(ir.Item()); err != nil {
+			return err
+		}
+		if ex.count > count {
+			ex.losses = append(ex.losses, ir.Losses()...)
+		}
+	}
+	if err := ir.Err(); err != nil {
+		return errors.Wrap(err, "read")
+	}
+	return ex.iw.Flush()
+}
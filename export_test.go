@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xackery/eqitem/item"
+)
+
+func TestExportReaderLosses(t *testing.T) {
+	input := "id|price\n1|4.5\n2|3.5\n3|7\n"
+	r, err := item.NewReader(context.Background(), strings.NewReader(input), "sodeq", &item.Mapping{Decimal: item.DecimalTruncate}, item.FailFast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	enc, err := item.NewEncoder(&bytes.Buffer{}, "jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := &idFilter{}
+	filter.addID(2)
+	filter.addID(3)
+	ex := &itemExporter{iw: enc, filter: filter}
+	if err = ex.exportReader(r); err != nil {
+		t.Fatal(err)
+	}
+	//item 1 lost precision but was not exported
+	if len(ex.losses) != 1 || ex.losses[0].ItemID != 2 {
+		t.Errorf("got losses %+v, want only item 2's", ex.losses)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// idFilter limits which item ids are imported. An empty filter matches every id
+type idFilter struct {
+	ids    map[int64]bool
+	ranges []idRange
+}
+
+// idRange is an inclusive range of item ids
+type idRange struct {
+	min int64
+	max int64
+}
+
+// isEmpty returns true if no ids or ranges were added
+func (f *idFilter) isEmpty() bool {
+	return len(f.ids) == 0 && len(f.ranges) == 0
+}
+
+// match returns true if id should be imported
+func (f *idFilter) match(id int64) bool {
+	if f.isEmpty() {
+		return true
+	}
+	if f.ids[id] {
+		return true
+	}
+	for _, r := range f.ranges {
+		if id >= r.min && id <= r.max {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *idFilter) addID(id int64) {
+	if f.ids == nil {
+		f.ids = map[int64]bool{}
+	}
+	f.ids[id] = true
+}
+
+// addIDs parses a comma or whitespace separated list of ids, e.g. 1001,1002
+func (f *idFilter) addIDs(value string) error {
+	for _, field := range strings.FieldsFunc(value, isIDSeparator) {
+		id, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "id %s", field)
+		}
+		f.addID(id)
+	}
+	return nil
+}
+
+// addRanges parses a comma separated list of inclusive ranges, e.g. 100000-150000
+func (f *idFilter) addRanges(value string) error {
+	for _, field := range strings.FieldsFunc(value, isIDSeparator) {
+		bounds := strings.SplitN(field, "-", 2)
+		if len(bounds) != 2 {
+			return fmt.Errorf("range %s: expected min-max", field)
+		}
+		min, err := strconv.ParseInt(bounds[0], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "range %s min", field)
+		}
+		max, err := strconv.ParseInt(bounds[1], 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "range %s max", field)
+		}
+		if min > max {
+			return fmt.Errorf("range %s: min is greater than max", field)
+		}
+		f.ranges = append(f.ranges, idRange{min: min, max: max})
+	}
+	return nil
+}
+
+// addFile reads ids from path, one or more per line. Text after a # is ignored
+func (f *idFilter) addFile(path string) error {
+	r, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	scanner := bufio.NewScanner(r)
+	lineCount := 0
+	for scanner.Scan() {
+		lineCount++
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		if err = f.addIDs(line); err != nil {
+			return errors.Wrapf(err, "line %d", lineCount)
+		}
+	}
+	return scanner.Err()
+}
+
+func isIDSeparator(r rune) bool {
+	return r == ',' || r == ' ' || r == '\t'
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/xackery/eqitem/item"
+)
+
+// importer decides what to do with each item parsed from items.txt. Items are written by a
+// goroutine per store, and what happened to them is reported in input order
+type importer struct {
+	schema   *item.Schema
+	stores   []ItemStore
+	dw       *diffWriter
+	remapper *idRemapper
+	spells   *spellChecker
+	overflow string
+	dropped  droppedColumns
+	isUpdate bool
+	// isPreloaded is true when store.Exists is cheap enough to check before fetching each item
+	isPreloaded bool
+	ids         []string
+	// checkpoints is told what happened to each item, so it can save how far the import got
+	checkpoints *checkpointWriter
+
+	seq      int
+	jobs     []chan *importJob
+	results  chan *importJob
+	writers  sync.WaitGroup
+	reported chan struct{}
+	mu       sync.Mutex
+	// failedSeq is the lowest seq of a failed write, later jobs are not written
+	failedSeq int
+	err       error
+}
+
+// importJob is an item on its way to a store, and what happened to it
+type importJob struct {
+	seq    int
+	writer int
+	line   int
+	item   *item.EQEmuItem
+	oldID  int64
+	//status is insert or update, or empty when nothing changed
+	status  string
+	changes []item.FieldChange
+	err     error
+}
+
+// importQueue is how many items can wait for each writer
+const importQueue = 64
+
+// start runs a writer goroutine for every store, and a goroutine reporting their results
+func (imp *importer) start() {
+	imp.failedSeq = -1
+	imp.results = make(chan *importJob, importQueue*len(imp.stores))
+	imp.reported = make(chan struct{})
+	for _, store := range imp.stores {
+		jobs := make(chan *importJob, importQueue)
+		imp.jobs = append(imp.jobs, jobs)
+		imp.writers.Add(1)
+		go func(store ItemStore) {
+			defer imp.writers.Done()
+			for job := range jobs {
+				if !imp.isAfterFailure(job.seq) {
+					imp.write(store, job)
+				}
+				if job.err != nil {
+					imp.fail(job.seq)
+				}
+				imp.results <- job
+			}
+		}(store)
+	}
+	go imp.report()
+}
+
+// process checks item and queues it for the writer of its id. An item id always goes to the same
+// writer, so writes to it happen in input order
+func (imp *importer) process(line int, item *item.EQEmuItem) error {
+	oldID := item.ID
+	if imp.remapper != nil {
+		var err error
+		if oldID, err = imp.remapper.remap(item); err != nil {
+			return err
+		}
+	}
+
+	if err := imp.checkOverflows(line, item); err != nil {
+		return err
+	}
+
+	if imp.spells != nil {
+		imp.spells.check(item)
+	}
+	imp.dropped.add(imp.schema, item)
+
+	if imp.isAfterFailure(imp.seq) {
+		return fmt.Errorf("stopped after a failed write")
+	}
+	job := &importJob{seq: imp.seq, writer: int(uint64(item.ID) % uint64(len(imp.jobs))), line: line, item: item, oldID: oldID}
+	imp.seq++
+	imp.jobs[job.writer] <- job
+	return nil
+}
+
+// finish waits for queued items to be written and reported, returning the error of the earliest line that failed
+func (imp *importer) finish() error {
+	if imp.results == nil {
+		return nil
+	}
+	for _, jobs := range imp.jobs {
+		close(jobs)
+	}
+	imp.writers.Wait()
+	close(imp.results)
+	<-imp.reported
+	imp.results = nil
+	return imp.err
+}
+
+// abort stops importing and rolls back every store. A write that failed on an earlier line is reported instead of err
+func (imp *importer) abort(err error) error {
+	if werr := imp.finish(); werr != nil {
+		err = werr
+	}
+	for _, store := range imp.stores {
+		err = store.Rollback(err)
+	}
+	return err
+}
+
+// commit commits every store, rolling back the rest if one fails. Stores commit one after another,
+// so the error says which were already committed
+func (imp *importer) commit() error {
+	for i, store := range imp.stores {
+		if err := store.Commit(); err != nil {
+			if i == 1 {
+				err = errors.Wrapf(err, "writer 2 of %d failed to commit after writer 1 committed", len(imp.stores))
+			} else if i > 1 {
+				err = errors.Wrapf(err, "writer %d of %d failed to commit after writers 1-%d committed", i+1, len(imp.stores), i)
+			}
+			for _, rest := range imp.stores[i+1:] {
+				err = rest.Rollback(err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *importer) isAfterFailure(seq int) bool {
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+	return imp.failedSeq >= 0 && seq > imp.failedSeq
+}
+
+func (imp *importer) fail(seq int) {
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+	if imp.failedSeq < 0 || seq < imp.failedSeq {
+		imp.failedSeq = seq
+	}
+}
+
+// write compares the job's item to store and inserts or updates it
+func (imp *importer) write(store ItemStore, job *importJob) {
+	item := job.item
+	if imp.isPreloaded {
+		exists, err := store.Exists(item.ID)
+		if err != nil {
+			job.err = errors.Wrap(err, "exists")
+			return
+		}
+		if !exists {
+			job.err = imp.insert(store, job)
+			return
+		}
+		if job.oldID != item.ID {
+			job.err = fmt.Errorf("id %d remapped to %d, which already exists", job.oldID, item.ID)
+			return
+		}
+		if !imp.isUpdate && imp.dw == nil {
+			return
+		}
+	}
+
+	oldItem, err := store.Get(item.ID)
+	if err != nil {
+		job.err = errors.Wrap(err, "old item")
+		return
+	}
+	if oldItem == nil {
+		job.err = imp.insert(store, job)
+		return
+	}
+	if job.oldID != item.ID {
+		job.err = fmt.Errorf("id %d remapped to %d, which already exists", job.oldID, item.ID)
+		return
+	}
+	job.err = imp.update(store, job, oldItem)
+}
+
+// report logs what happened to each item in input order, holding back results that arrive early
+func (imp *importer) report() {
+	defer close(imp.reported)
+	early := map[int]*importJob{}
+	next := 0
+	for job := range imp.results {
+		early[job.seq] = job
+		for {
+			job, ok := early[next]
+			if !ok {
+				break
+			}
+			delete(early, next)
+			next++
+			if imp.err != nil {
+				continue
+			}
+			if job.err != nil {
+				imp.err = job.err
+				continue
+			}
+			imp.err = imp.reportJob(job)
+			if imp.checkpoints != nil {
+				imp.checkpoints.reported(job.writer, job.line, job.oldID, job.status != "")
+			}
+		}
+	}
+}
+
+func (imp *importer) reportJob(job *importJob) error {
+	if job.status == "" {
+		return nil
+	}
+	if imp.dw != nil {
+		if err := imp.dw.Write(&itemDiff{ID: job.item.ID, Status: job.status, Changes: job.changes}); err != nil {
+			return errors.Wrap(err, "diff write")
+		}
+		return nil
+	}
+	if job.status == "insert" {
+		log.Info().Msgf("inserted %d", job.item.ID)
+	} else {
+		log.Info().Msgf("updated %d", job.item.ID)
+	}
+	imp.ids = append(imp.ids, fmt.Sprintf("%d", job.item.ID))
+	return nil
+}
+
+// checkOverflows applies the overflow policy to values that do not fit their column: warn, clamp or error
+func (imp *importer) checkOverflows(line int, item *item.EQEmuItem) error {
+	overflows := imp.schema.Overflows(item, imp.overflow == "clamp")
+	if len(overflows) == 0 {
+		return nil
+	}
+	if imp.overflow == "error" {
+		return fmt.Errorf("item %d column %s: %s", item.ID, overflows[0].Column, overflows[0].Message)
+	}
+	for _, overflow := range overflows {
+		msg := "overflow"
+		if imp.overflow == "clamp" {
+			msg = "clamped"
+		}
+		log.Warn().Int("line", line).Int64("id", item.ID).Str("column", overflow.Column).Msgf("%s: %s", msg, overflow.Message)
+	}
+	return nil
+}
+
+func (imp *importer) insert(store ItemStore, job *importJob) error {
+	if imp.dw == nil {
+		if err := store.Insert(job.line, job.item); err != nil {
+			return errors.Wrapf(err, "insert %d", job.item.ID)
+		}
+	}
+	job.status = "insert"
+	return nil
+}
+
+func (imp *importer) update(store ItemStore, job *importJob, oldItem *item.EQEmuItem) error {
+	changes := job.item.ChangedFields(imp.schema, oldItem)
+	if len(changes) == 0 {
+		return nil
+	}
+	if imp.dw != nil {
+		job.status = "update"
+		job.changes = changes
+		return nil
+	}
+	if !imp.isUpdate {
+		return nil
+	}
+
+	if err := store.Update(job.line, job.item); err != nil {
+		return errors.Wrapf(err, "update %d", job.item.ID)
+	}
+	job.status = "update"
+	return nil
+}
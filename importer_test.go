@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/xackery/eqitem/item"
+)
+
+// importItems runs items through imp the way run does, committing them if every item succeeds
+func importItems(imp *importer, items ...*item.EQEmuItem) error {
+	imp.start()
+	for i, it := range items {
+		if err := imp.process(i+1, it); err != nil {
+			return imp.abort(err)
+		}
+	}
+	if err := imp.finish(); err != nil {
+		return imp.abort(err)
+	}
+	return imp.commit()
+}
+
+// newTestImporter returns an importer writing to stores
+func newTestImporter(stores ...ItemStore) *importer {
+	return &importer{
+		overflow: "warn",
+		dropped:  droppedColumns{},
+		stores:   stores,
+	}
+}
+
+// failingStore fails to insert the item with id
+type failingStore struct {
+	*memoryItemStore
+	id int64
+}
+
+func (st *failingStore) Insert(line int, it *item.EQEmuItem) error {
+	if it.ID == st.id {
+		return fmt.Errorf("insert failed")
+	}
+	return st.memoryItemStore.Insert(line, it)
+}
+
+// uncommittableStore fails to commit
+type uncommittableStore struct {
+	*memoryItemStore
+}
+
+func (st *uncommittableStore) Commit() error {
+	return fmt.Errorf("commit failed")
+}
+
+func storedName(t *testing.T, st ItemStore, id int64) string {
+	t.Helper()
+	it, err := st.Get(id)
+	if err != nil {
+		t.Fatalf("get %d: %v", id, err)
+	}
+	if it == nil {
+		return ""
+	}
+	return it.Name
+}
+
+func TestImporterInsert(t *testing.T) {
+	st := newMemoryItemStore()
+	st.items[2] = &item.EQEmuItem{ID: 2, Name: "old"}
+	imp := newTestImporter(st)
+	err := importItems(imp, &item.EQEmuItem{ID: 1, Name: "one"}, &item.EQEmuItem{ID: 2, Name: "new"}, &item.EQEmuItem{ID: 3, Name: "three"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(imp.ids, ","); got != "1,3" {
+		t.Errorf("ids: got %s, want 1,3", got)
+	}
+	//existing items are left alone without --update
+	if got := storedName(t, st, 2); got != "old" {
+		t.Errorf("item 2: got %s, want old", got)
+	}
+	if got := storedName(t, st, 3); got != "three" {
+		t.Errorf("item 3: got %s, want three", got)
+	}
+}
+
+func TestImporterUpdate(t *testing.T) {
+	st := newMemoryItemStore()
+	st.items[1] = &item.EQEmuItem{ID: 1, Name: "same"}
+	st.items[2] = &item.EQEmuItem{ID: 2, Name: "old"}
+	imp := newTestImporter(st)
+	imp.isUpdate = true
+	err := importItems(imp, &item.EQEmuItem{ID: 1, Name: "same"}, &item.EQEmuItem{ID: 2, Name: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(imp.ids, ","); got != "2" {
+		t.Errorf("ids: got %s, want 2", got)
+	}
+	if got := storedName(t, st, 2); got != "new" {
+		t.Errorf("item 2: got %s, want new", got)
+	}
+}
+
+func TestImporterDiff(t *testing.T) {
+	st := newMemoryItemStore()
+	st.items[2] = &item.EQEmuItem{ID: 2, Name: "old"}
+	imp := newTestImporter(st)
+	out := &bytes.Buffer{}
+	var err error
+	imp.dw, err = newDiffWriter(out, "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = importItems(imp, &item.EQEmuItem{ID: 1}, &item.EQEmuItem{ID: 2, Name: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = imp.dw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "id,status,field,old,new\n1,insert,,,\n2,update,Name,old,new\n"
+	if out.String() != want {
+		t.Errorf("diff: got %q, want %q", out.String(), want)
+	}
+	//a diff never writes
+	if got := storedName(t, st, 2); got != "old" {
+		t.Errorf("item 2: got %s, want old", got)
+	}
+	if ok, _ := st.Exists(1); ok {
+		t.Errorf("item 1 was inserted by a diff")
+	}
+}
+
+func TestImporterRemapCollision(t *testing.T) {
+	st := newMemoryItemStore()
+	st.items[101] = &item.EQEmuItem{ID: 101}
+	imp := newTestImporter(st)
+	var err error
+	imp.remapper, err = newIDRemapper(100, map[int64]int64{}, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = importItems(imp, &item.EQEmuItem{ID: 2}, &item.EQEmuItem{ID: 1})
+	want := "rolled back 1 items: id 1 remapped to 101, which already exists"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got error %v, want %s", err, want)
+	}
+	if ok, _ := st.Exists(102); ok {
+		t.Errorf("item 102 was not rolled back")
+	}
+
+	imp = newTestImporter(newMemoryItemStore())
+	imp.remapper, err = newIDRemapper(0, map[int64]int64{5: 6}, ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = importItems(imp, &item.EQEmuItem{ID: 5}, &item.EQEmuItem{ID: 6})
+	want = "rolled back 1 items: id 5 and 6 both remap to 6"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got error %v, want %s", err, want)
+	}
+}
+
+func TestImporterRollback(t *testing.T) {
+	for _, writers := range []int{1, 3} {
+		stores := []ItemStore{}
+		for i := 0; i < writers; i++ {
+			st := newMemoryItemStore()
+			st.items[1000] = &item.EQEmuItem{ID: 1000, Name: "committed"}
+			stores = append(stores, &failingStore{memoryItemStore: st, id: 7})
+		}
+		imp := newTestImporter(stores...)
+		items := []*item.EQEmuItem{}
+		for id := int64(1); id <= 20; id++ {
+			items = append(items, &item.EQEmuItem{ID: id})
+		}
+		err := importItems(imp, items...)
+		if err == nil || !strings.HasSuffix(err.Error(), "insert 7: insert failed") {
+			t.Fatalf("%d writers: got error %v, want insert 7 to fail", writers, err)
+		}
+		//every write before the failed line is reported, in input order, and nothing after it
+		if got := strings.Join(imp.ids, ","); got != "1,2,3,4,5,6" {
+			t.Errorf("%d writers: ids: got %s, want 1,2,3,4,5,6", writers, got)
+		}
+		for _, st := range stores {
+			if ok, _ := st.Exists(1); ok {
+				t.Errorf("%d writers: item 1 was not rolled back", writers)
+			}
+			if got := storedName(t, st, 1000); got != "committed" {
+				t.Errorf("%d writers: committed item 1000 was lost", writers)
+			}
+		}
+	}
+}
+
+func TestImporterCommitFailure(t *testing.T) {
+	first, last := newMemoryItemStore(), newMemoryItemStore()
+	imp := newTestImporter(first, &uncommittableStore{newMemoryItemStore()}, last)
+	items := []*item.EQEmuItem{}
+	for id := int64(1); id <= 6; id++ {
+		items = append(items, &item.EQEmuItem{ID: id})
+	}
+	err := importItems(imp, items...)
+	want := "rolled back 2 items: writer 2 of 3 failed to commit after writer 1 committed: commit failed"
+	if err == nil || err.Error() != want {
+		t.Fatalf("got error %v, want %s", err, want)
+	}
+	if ok, _ := first.Exists(3); !ok {
+		t.Errorf("item 3 was not committed by the first writer")
+	}
+	if ok, _ := last.Exists(2); ok {
+		t.Errorf("item 2 was not rolled back by the last writer")
+	}
+}
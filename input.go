@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// gzipMagic is the header every gzip stream starts with
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// inputFile wraps the opened items.txt so closing it closes the decompressor and the file
+type inputFile struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close closes every underlying reader
+func (in *inputFile) Close() error {
+	var err error
+	for i := len(in.closers) - 1; i >= 0; i-- {
+		if cerr := in.closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// openInput opens path, or stdin if path is -, transparently decompressing gzip data
+func openInput(path string) (io.ReadCloser, error) {
+	in := &inputFile{}
+	var f io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		in.closers = append(in.closers, file)
+		f = file
+	}
+
+	br := bufio.NewReader(f)
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		in.Close()
+		return nil, errors.Wrap(err, "peek")
+	}
+	in.Reader = br
+	if len(magic) == len(gzipMagic) && magic[0] == gzipMagic[0] && magic[1] == gzipMagic[1] {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			in.Close()
+			return nil, errors.Wrap(err, "gzip")
+		}
+		in.closers = append(in.closers, gz)
+		in.Reader = gz
+	}
+	return in, nil
+}
@@ -0,0 +1,101 @@
+package item
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Decimal policies, for how a decimal value is stored in an integer field
+const (
+	// DecimalTruncate drops the fraction, e.g. 4.7 is stored as 4
+	DecimalTruncate = "truncate"
+	// DecimalRound rounds half away from zero, e.g. 4.5 is stored as 5 and -4.5 as -5
+	DecimalRound = "round"
+	// DecimalReject fails the field, and the line it is on
+	DecimalReject = "reject"
+)
+
+// IsDecimalPolicy returns true if policy is a known way of storing decimals in integer fields
+func IsDecimalPolicy(policy string) bool {
+	return policy == DecimalTruncate || policy == DecimalRound || policy == DecimalReject
+}
+
+// PrecisionLoss is an integer field whose decimal value could not be stored exactly
+type PrecisionLoss struct {
+	ItemID int64
+	Field  string
+	Value  string
+}
+
+// parseDecimalInt parses value for an integer field, handling decimals according to policy.
+// It returns true if precision was lost
+func parseDecimalInt(value string, policy string) (int64, bool, error) {
+	if value == "" {
+		return 0, false, nil
+	}
+	if strings.ContainsAny(value, "eE") {
+		return parseExponentInt(value, policy)
+	}
+	idx := strings.Index(value, ".")
+	if idx < 0 {
+		val, err := strconv.ParseInt(value, 10, 64)
+		return val, false, err
+	}
+	fraction := value[idx+1:]
+	if strings.Trim(fraction, "0123456789") != "" {
+		return 0, false, fmt.Errorf("invalid decimal %s", value)
+	}
+	if strings.Trim(fraction, "0") == "" {
+		val, err := parseIntPrefix(value[:idx])
+		return val, false, err
+	}
+	if policy == DecimalReject {
+		return 0, false, fmt.Errorf("decimal value %s in integer field", value)
+	}
+	val, err := parseIntPrefix(value[:idx])
+	if err != nil {
+		return 0, false, err
+	}
+	//rounding looks at the digits instead of going through a float, which would lose precision on large values
+	if policy == DecimalRound && fraction[0] >= '5' {
+		if strings.HasPrefix(value, "-") {
+			val--
+		} else {
+			val++
+		}
+	}
+	return val, true, nil
+}
+
+// parseExponentInt parses a value in exponent notation, e.g. 1.5e3, for an integer field
+func parseExponentInt(value string, policy string) (int64, bool, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	isLossy := f != math.Trunc(f)
+	if isLossy {
+		switch policy {
+		case DecimalReject:
+			return 0, false, fmt.Errorf("decimal value %s in integer field", value)
+		case DecimalRound:
+			f = math.Round(f)
+		default:
+			f = math.Trunc(f)
+		}
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false, fmt.Errorf("value %s out of range", value)
+	}
+	return int64(f), isLossy, nil
+}
+
+// parseIntPrefix parses the whole number part of a decimal, which may be empty or just a sign
+func parseIntPrefix(value string) (int64, error) {
+	if value == "" || value == "-" || value == "+" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
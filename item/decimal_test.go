@@ -0,0 +1,65 @@
+package item
+
+import "testing"
+
+func TestParseDecimalInt(t *testing.T) {
+	tests := []struct {
+		value   string
+		policy  string
+		want    int64
+		isLossy bool
+		err     bool
+	}{
+		{value: "", policy: DecimalTruncate, want: 0},
+		{value: "42", policy: DecimalReject, want: 42},
+		{value: "-42", policy: DecimalRound, want: -42},
+		{value: "4.000", policy: DecimalReject, want: 4},
+		{value: "4.", policy: DecimalReject, want: 4},
+		{value: "-0.0", policy: DecimalReject, want: 0},
+
+		{value: "4.7", policy: DecimalTruncate, want: 4, isLossy: true},
+		{value: "-4.7", policy: DecimalTruncate, want: -4, isLossy: true},
+		{value: ".5", policy: DecimalTruncate, want: 0, isLossy: true},
+		{value: "-.5", policy: DecimalTruncate, want: 0, isLossy: true},
+
+		{value: "4.5", policy: DecimalRound, want: 5, isLossy: true},
+		{value: "4.49", policy: DecimalRound, want: 4, isLossy: true},
+		{value: "-4.5", policy: DecimalRound, want: -5, isLossy: true},
+		{value: "-4.49", policy: DecimalRound, want: -4, isLossy: true},
+		{value: "-0.5", policy: DecimalRound, want: -1, isLossy: true},
+		{value: "+.5", policy: DecimalRound, want: 1, isLossy: true},
+		//past float64 precision
+		{value: "9007199254740993.5", policy: DecimalRound, want: 9007199254740994, isLossy: true},
+
+		{value: "4.5", policy: DecimalReject, err: true},
+		{value: "-0.01", policy: DecimalReject, err: true},
+
+		{value: "1e3", policy: DecimalReject, want: 1000},
+		{value: "1.5E3", policy: DecimalReject, want: 1500},
+		{value: "-2.5e0", policy: DecimalTruncate, want: -2, isLossy: true},
+		{value: "2.5e0", policy: DecimalRound, want: 3, isLossy: true},
+		{value: "1.5e-1", policy: DecimalReject, err: true},
+		{value: "1e30", policy: DecimalTruncate, err: true},
+
+		{value: "abc", policy: DecimalTruncate, err: true},
+		{value: "4.5x", policy: DecimalTruncate, err: true},
+		{value: "x.5", policy: DecimalRound, err: true},
+		{value: "99999999999999999999", policy: DecimalTruncate, err: true},
+	}
+	for _, tt := range tests {
+		got, isLossy, err := parseDecimalInt(tt.value, tt.policy)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s %s: got %d, want an error", tt.policy, tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %s: %v", tt.policy, tt.value, err)
+			continue
+		}
+		if got != tt.want || isLossy != tt.isLossy {
+			t.Errorf("%s %s: got %d (lossy %t), want %d (lossy %t)", tt.policy, tt.value, got, isLossy, tt.want, tt.isLossy)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package item
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// FieldChange is a single db column that differs between items.txt and the items table
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// FieldValue renders a struct field value as text for reporting
+func FieldValue(value interface{}) string {
+	if valuer, ok := value.(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err != nil || val == nil {
+			return "NULL"
+		}
+		value = val
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,340 @@
+package item
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Decoder reads one item at a time from an input format
+type Decoder interface {
+	// Decode returns the next item, or io.EOF when the input is done.
+	// A *LineError only affects the current line, and reading may continue
+	Decode() (*EQEmuItem, error)
+	// Line returns the line number of the last item read
+	Line() int
+}
+
+// Encoder writes items in an output format
+type Encoder interface {
+	Encode(item *EQEmuItem) error
+	Flush() error
+}
+
+// LineError is a Decode error for a single malformed line
+type LineError struct {
+	err error
+}
+
+func (e *LineError) Error() string {
+	return e.err.Error()
+}
+
+// IsLineError returns true if reading may continue after err
+func IsLineError(err error) bool {
+	_, ok := err.(*LineError)
+	return ok
+}
+
+// NewDecoder returns a reader for format: sodeq (pipe delimited items.txt), csv or jsonl.
+// csv and jsonl use db column names, and sodeq headers are read through mapping
+func NewDecoder(r io.Reader, format string, mapping *Mapping) (Decoder, error) {
+	switch format {
+	case "sodeq":
+		cr := csv.NewReader(r)
+		cr.Comma = '|'
+		cr.LazyQuotes = true
+		return &csvDecoder{r: cr, tagKey: "sodaeq", mapping: mapping}, nil
+	case "csv":
+		cr := csv.NewReader(r)
+		cr.LazyQuotes = true
+		return &csvDecoder{r: cr, tagKey: "db", mapping: mapping}, nil
+	case "jsonl":
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		return &jsonlDecoder{scanner: scanner, mapping: mapping}, nil
+	}
+	return nil, fmt.Errorf("unknown input format: %s", format)
+}
+
+// NewEncoder returns a writer for format: sodeq (pipe delimited items.txt), csv or jsonl
+func NewEncoder(w io.Writer, format string) (Encoder, error) {
+	switch format {
+	case "sodeq":
+		cw := csv.NewWriter(w)
+		cw.Comma = '|'
+		return &csvEncoder{w: cw, tagKey: "sodaeq"}, nil
+	case "csv":
+		return &csvEncoder{w: csv.NewWriter(w), tagKey: "db"}, nil
+	case "jsonl":
+		return &jsonlEncoder{w: bufio.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown output format: %s", format)
+}
+
+// TagHeader returns every tagKey tag, in struct order
+func TagHeader(tagKey string) []string {
+	header := []string{}
+	st := reflect.TypeOf(EQEmuItem{})
+	for i := 0; i < st.NumField(); i++ {
+		tag, ok := st.Field(i).Tag.Lookup(tagKey)
+		if !ok {
+			continue
+		}
+		header = append(header, tag)
+	}
+	return header
+}
+
+// TagRecord renders every field with a tagKey tag as text, matching TagHeader
+func (item *EQEmuItem) TagRecord(tagKey string) []string {
+	record := []string{}
+	st := reflect.TypeOf(*item)
+	s := reflect.ValueOf(item).Elem()
+	for i := 0; i < st.NumField(); i++ {
+		if _, ok := st.Field(i).Tag.Lookup(tagKey); !ok {
+			continue
+		}
+		record = append(record, TextValue(s.Field(i).Interface()))
+	}
+	return record
+}
+
+// recordDecoder splits decoding in two: next reads raw records in order, and decode builds
+// their items, which is safe to do from several goroutines at once
+type recordDecoder interface {
+	Decoder
+	next() (interface{}, error)
+	decode(record interface{}) (*EQEmuItem, []*PrecisionLoss, error)
+}
+
+// decodeNext reads and decodes the next record of dec, adding any precision lost to mapping
+func decodeNext(dec recordDecoder, mapping *Mapping) (*EQEmuItem, error) {
+	record, err := dec.next()
+	if err != nil {
+		return nil, err
+	}
+	item, losses, err := dec.decode(record)
+	if err != nil {
+		return nil, err
+	}
+	mapping.addLosses(losses)
+	return item, nil
+}
+
+// csvDecoder reads a delimited file whose first record is a header of tagKey names
+type csvDecoder struct {
+	r         *csv.Reader
+	tagKey    string
+	mapping   *Mapping
+	plan      *Plan
+	lineCount int
+}
+
+func (ir *csvDecoder) Line() int {
+	return ir.lineCount
+}
+
+func (ir *csvDecoder) Decode() (*EQEmuItem, error) {
+	return decodeNext(ir, ir.mapping)
+}
+
+// next reads the next record, building the plan from the header first. A header the plan
+// cannot decode fails the whole file, not just a line
+func (ir *csvDecoder) next() (interface{}, error) {
+	if ir.plan != nil && ir.plan.err != nil {
+		return nil, ir.plan.err
+	}
+	for {
+		ir.lineCount++
+		record, err := ir.r.Read()
+		if _, ok := err.(*csv.ParseError); ok {
+			return nil, &LineError{err: err}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ir.plan == nil {
+			ir.plan = newPlan(ir.tagKey, ir.mapping, record)
+			if ir.plan.err != nil {
+				return nil, ir.plan.err
+			}
+			continue
+		}
+		return record, nil
+	}
+}
+
+func (ir *csvDecoder) decode(record interface{}) (*EQEmuItem, []*PrecisionLoss, error) {
+	item, losses, err := ir.plan.decode(record.([]string))
+	if err != nil {
+		return nil, nil, &LineError{err: err}
+	}
+	return item, losses, nil
+}
+
+// jsonlDecoder reads one json object per line, keyed by db column names
+type jsonlDecoder struct {
+	scanner   *bufio.Scanner
+	mapping   *Mapping
+	lineCount int
+}
+
+func (ir *jsonlDecoder) Line() int {
+	return ir.lineCount
+}
+
+func (ir *jsonlDecoder) Decode() (*EQEmuItem, error) {
+	return decodeNext(ir, ir.mapping)
+}
+
+// next reads the next non blank line
+func (ir *jsonlDecoder) next() (interface{}, error) {
+	for {
+		if !ir.scanner.Scan() {
+			if err := ir.scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		ir.lineCount++
+		line := bytes.TrimSpace(ir.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		//the scanner reuses its buffer, and the line may be decoded after the next scan
+		return append([]byte{}, line...), nil
+	}
+}
+
+func (ir *jsonlDecoder) decode(record interface{}) (*EQEmuItem, []*PrecisionLoss, error) {
+	dec := json.NewDecoder(bytes.NewReader(record.([]byte)))
+	dec.UseNumber()
+	fields := map[string]interface{}{}
+	if err := dec.Decode(&fields); err != nil {
+		return nil, nil, &LineError{err: errors.Wrap(err, "decode")}
+	}
+	header := []string{}
+	values := []string{}
+	for key, value := range fields {
+		header = append(header, key)
+		switch v := value.(type) {
+		case nil:
+			values = append(values, "")
+		case string:
+			values = append(values, v)
+		case json.Number:
+			values = append(values, v.String())
+		default:
+			return nil, nil, &LineError{err: fmt.Errorf("field %s: unsupported value %v", key, value)}
+		}
+	}
+	item, losses, err := newPlan("db", ir.mapping, header).decode(values)
+	if err != nil {
+		return nil, nil, &LineError{err: err}
+	}
+	return item, losses, nil
+}
+
+// csvEncoder writes a delimited file with a header of tagKey names
+type csvEncoder struct {
+	w        *csv.Writer
+	tagKey   string
+	isHeader bool
+}
+
+func (iw *csvEncoder) Encode(item *EQEmuItem) error {
+	if !iw.isHeader {
+		iw.isHeader = true
+		if err := iw.w.Write(TagHeader(iw.tagKey)); err != nil {
+			return errors.Wrap(err, "header")
+		}
+	}
+	return iw.w.Write(item.TagRecord(iw.tagKey))
+}
+
+func (iw *csvEncoder) Flush() error {
+	if !iw.isHeader {
+		iw.isHeader = true
+		if err := iw.w.Write(TagHeader(iw.tagKey)); err != nil {
+			return errors.Wrap(err, "header")
+		}
+	}
+	iw.w.Flush()
+	return iw.w.Error()
+}
+
+// jsonlEncoder writes one json object per item, keyed by db column names in struct order
+type jsonlEncoder struct {
+	w *bufio.Writer
+}
+
+func (iw *jsonlEncoder) Encode(item *EQEmuItem) error {
+	st := reflect.TypeOf(*item)
+	s := reflect.ValueOf(item).Elem()
+
+	fields := []string{}
+	for i := 0; i < st.NumField(); i++ {
+		tag, ok := st.Field(i).Tag.Lookup("db")
+		if !ok {
+			continue
+		}
+		value := s.Field(i).Interface()
+		switch v := value.(type) {
+		case sql.NullString:
+			value = nil
+			if v.Valid {
+				value = v.String
+			}
+		case NullTime:
+			value = nil
+			if v.Valid {
+				value = v.Time.Format(TimeFormat)
+			}
+		}
+		key, err := json.Marshal(tag)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return errors.Wrapf(err, "field %s", tag)
+		}
+		fields = append(fields, string(key)+":"+string(data))
+	}
+	_, err := fmt.Fprintf(iw.w, "{%s}\n", strings.Join(fields, ","))
+	return err
+}
+
+func (iw *jsonlEncoder) Flush() error {
+	return iw.w.Flush()
+}
+
+// TextValue renders a field the way items.txt stores it, so it parses back through a Plan
+func TextValue(value interface{}) string {
+	switch v := value.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	case sql.NullString:
+		return v.String
+	case NullTime:
+		if !v.Valid {
+			return ""
+		}
+		return v.Time.Format(TimeFormat)
+	}
+	return ""
+}
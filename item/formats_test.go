@@ -0,0 +1,67 @@
+package item
+
+import (
+	"bytes"
+	"database/sql"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripItems are items with the values most likely to be mangled by a text format
+func roundTripItems() []*EQEmuItem {
+	serialized := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []*EQEmuItem{
+		{ID: 1001, Name: "Cloth Cap", Classes: 65535, Price: 4, Sellrate: 1.25},
+		{ID: 1002, Name: "Pipe | Dream", Lore: "*Pipe|Dream", Idfile: "IT63"},
+		{ID: 1003, Name: `The "Quoted" Cap`, Lore: `"`, Comment: `say ""hail""`},
+		{ID: 1004, Name: "Comma, Cap", Lore: ", , ,", Filename: "a,b|c\"d"},
+		{ID: 1005, Name: "Plain", UNK132: sql.NullString{String: "not null", Valid: true}, Serialized: NullTime{Time: serialized, Valid: true}},
+		{ID: 1006, Name: "Nulls", UNK132: sql.NullString{}, Serialized: NullTime{}, Serialization: NullTime{}},
+		{ID: -5, Name: "Negative", Ac: -32768, Sellrate: -0.5, Updated: "2020-01-02 03:04:05"},
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	schema := &Schema{columns: map[string]*ColumnDef{}}
+	for _, tag := range TagHeader("db") {
+		schema.columns[strings.ToLower(tag)] = &ColumnDef{Name: tag}
+	}
+	if !schema.Has("Name") || !schema.Has("freestorage") {
+		t.Fatal("schema is missing columns")
+	}
+	for _, format := range []string{"sodeq", "csv", "jsonl"} {
+		items := roundTripItems()
+		buf := &bytes.Buffer{}
+		enc, err := NewEncoder(buf, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, it := range items {
+			if err = enc.Encode(it); err != nil {
+				t.Fatalf("%s: encode %d: %v", format, it.ID, err)
+			}
+		}
+		if err = enc.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		dec, err := NewDecoder(bytes.NewReader(buf.Bytes()), format, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range items {
+			got, err := dec.Decode()
+			if err != nil {
+				t.Fatalf("%s: decode %d on line %d: %v", format, want.ID, dec.Line(), err)
+			}
+			if changes := got.ChangedFields(schema, want); len(changes) > 0 {
+				t.Errorf("%s: item %d changed: %+v", format, want.ID, changes)
+			}
+		}
+		if _, err = dec.Decode(); err != io.EOF {
+			t.Errorf("%s: got %v after the last item, want io.EOF", format, err)
+		}
+	}
+}
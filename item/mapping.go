@@ -0,0 +1,171 @@
+package item
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Mapping overrides how items.txt headers are read into EQEmuItem, loaded from a json file
+type Mapping struct {
+	Fields map[string]*FieldMapping `json:"fields"`
+	// Columns renames db columns to the name a particular items table schema uses, keyed by db tag
+	Columns map[string]string `json:"columns"`
+
+	// Decimal is the policy for fields without their own
+	Decimal string `json:"-"`
+	// Losses records every integer field that lost precision in items from Plan.Decode and Decoder.Decode.
+	// A Reader leaves them to the caller, see Reader.Losses
+	Losses []*PrecisionLoss `json:"-"`
+}
+
+// FieldMapping describes how a single items.txt header is read
+type FieldMapping struct {
+	// Column is the db column to set, defaulting to the field with a matching sodaeq tag
+	Column string `json:"column,omitempty"`
+	// Skip ignores the header entirely
+	Skip bool `json:"skip,omitempty"`
+	// Default is used when the value is empty or the header is missing from items.txt
+	Default *string `json:"default,omitempty"`
+	// Transforms are applied in order to the raw value before it is parsed
+	Transforms []string `json:"transforms,omitempty"`
+	// Decimal is how decimals are stored in an integer column: round, truncate or reject
+	Decimal string `json:"decimal,omitempty"`
+}
+
+// LoadMapping reads and validates a mapping file
+func LoadMapping(path string) (*Mapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mapping := &Mapping{}
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(mapping); err != nil {
+		return nil, errors.Wrap(err, "decode")
+	}
+
+	for tag := range mapping.Columns {
+		if !hasTag("db", tag) {
+			return nil, fmt.Errorf("columns: no db tag %s found", tag)
+		}
+	}
+
+	for header, fm := range mapping.Fields {
+		if fm == nil {
+			return nil, fmt.Errorf("header %s: empty mapping", header)
+		}
+		if fm.Skip {
+			continue
+		}
+		if fm.Decimal != "" && !IsDecimalPolicy(fm.Decimal) {
+			return nil, fmt.Errorf("header %s: unknown decimal policy %s", header, fm.Decimal)
+		}
+		tagKey, tagName := fm.target(header)
+		if !hasTag(tagKey, tagName) {
+			return nil, fmt.Errorf("header %s: no %s tag %s found", header, tagKey, tagName)
+		}
+		for _, transform := range fm.Transforms {
+			if _, _, err = parseTransform(transform); err != nil {
+				return nil, errors.Wrapf(err, "header %s: transform %s", header, transform)
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// target returns the struct tag that header is written to
+func (fm *FieldMapping) target(header string) (tagKey string, tagName string) {
+	if fm.Column != "" {
+		return "db", fm.Column
+	}
+	return "sodaeq", header
+}
+
+// value applies the default and transforms to a raw value
+func (fm *FieldMapping) value(value string) (string, error) {
+	var err error
+	if value == "" && fm.Default != nil {
+		value = *fm.Default
+	}
+	for _, transform := range fm.Transforms {
+		value, err = applyTransform(transform, value)
+		if err != nil {
+			return "", errors.Wrapf(err, "transform %s", transform)
+		}
+	}
+	return value, nil
+}
+
+// hasTag returns true if an EQEmuItem field has a tagKey tag of tagName
+func hasTag(tagKey string, tagName string) bool {
+	_, ok := fieldIndex(tagKey, tagName)
+	return ok
+}
+
+// parseTransform splits a transform into its name and numeric operand.
+// Supported transforms are trim, lower, upper, and multiply:N, divide:N, add:N for numbers
+func parseTransform(transform string) (name string, operand float64, err error) {
+	name = transform
+	arg := ""
+	if idx := strings.Index(transform, ":"); idx >= 0 {
+		name = transform[:idx]
+		arg = transform[idx+1:]
+	}
+
+	switch name {
+	case "trim", "lower", "upper":
+		return name, 0, nil
+	case "multiply", "divide", "add":
+		operand, err = strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", 0, errors.Wrap(err, "operand")
+		}
+		if name == "divide" && operand == 0 {
+			return "", 0, fmt.Errorf("divide by zero")
+		}
+		return name, operand, nil
+	}
+	return "", 0, fmt.Errorf("unknown transform")
+}
+
+// applyTransform runs a transform on value
+func applyTransform(transform string, value string) (string, error) {
+	name, operand, err := parseTransform(transform)
+	if err != nil {
+		return "", err
+	}
+
+	switch name {
+	case "trim":
+		return strings.TrimSpace(value), nil
+	case "lower":
+		return strings.ToLower(value), nil
+	case "upper":
+		return strings.ToUpper(value), nil
+	}
+
+	if value == "" {
+		value = "0"
+	}
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "", err
+	}
+	switch name {
+	case "multiply":
+		val *= operand
+	case "divide":
+		val /= operand
+	case "add":
+		val += operand
+	}
+	return strconv.FormatFloat(val, 'f', -1, 64), nil
+}
@@ -0,0 +1,39 @@
+package item
+
+import "reflect"
+
+// OptionalColumns are db tags of the fields tagged eqitem:"optional", sodeq fields that only some eqemu schemas
+// have a column for. They are written when the items table has the column, and dropped otherwise
+var OptionalColumns = optionalColumns()
+
+func optionalColumns() map[string]bool {
+	columns := map[string]bool{}
+	st := reflect.TypeOf(EQEmuItem{})
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		tag, ok := field.Tag.Lookup("db")
+		if !ok || field.Tag.Get("eqitem") != "optional" {
+			continue
+		}
+		columns[tag] = true
+	}
+	return columns
+}
+
+// Dropped returns every optional column item has a value for that the items table is missing
+func (schema *Schema) Dropped(item *EQEmuItem) []string {
+	tags := []string{}
+	st := reflect.TypeOf(*item)
+	s := reflect.ValueOf(item).Elem()
+	for i := 0; i < st.NumField(); i++ {
+		tag, ok := st.Field(i).Tag.Lookup("db")
+		if !ok || !OptionalColumns[tag] || schema.Has(tag) {
+			continue
+		}
+		if s.Field(i).IsZero() {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
@@ -0,0 +1,128 @@
+package item
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ColumnOverflow is an item value that does not fit its items table column
+type ColumnOverflow struct {
+	Column  string
+	Value   string
+	Message string
+}
+
+// intRange returns the values an integer column can hold, and false if it is not an integer column
+func (col *ColumnDef) intRange() (min int64, max int64, ok bool) {
+	isUnsigned := strings.Contains(strings.ToLower(col.ColumnType), "unsigned")
+	var bits uint
+	switch strings.ToLower(col.DataType) {
+	case "tinyint":
+		bits = 8
+	case "smallint":
+		bits = 16
+	case "mediumint":
+		bits = 24
+	case "int", "integer":
+		bits = 32
+	case "bigint":
+		if isUnsigned {
+			return 0, math.MaxInt64, true
+		}
+		return math.MinInt64, math.MaxInt64, true
+	default:
+		return 0, 0, false
+	}
+	if isUnsigned {
+		return 0, 1<<bits - 1, true
+	}
+	return -(1 << (bits - 1)), 1<<(bits-1) - 1, true
+}
+
+// Overflows returns every value of item that does not fit its column.
+// If clamp is true, integers are clamped to the column's range and strings are truncated
+func (schema *Schema) Overflows(item *EQEmuItem, clamp bool) []*ColumnOverflow {
+	if schema == nil {
+		return nil
+	}
+	overflows := []*ColumnOverflow{}
+	st := reflect.TypeOf(*item)
+	s := reflect.ValueOf(item).Elem()
+
+	for i := 0; i < st.NumField(); i++ {
+		tag, ok := st.Field(i).Tag.Lookup("db")
+		if !ok {
+			continue
+		}
+		col := schema.Column(tag)
+		if col == nil {
+			continue
+		}
+		pf := s.Field(i)
+
+		switch pf.Kind() {
+		case reflect.Int64:
+			min, max, ok := col.intRange()
+			if !ok {
+				continue
+			}
+			val := pf.Int()
+			if val >= min && val <= max {
+				continue
+			}
+			overflows = append(overflows, &ColumnOverflow{
+				Column:  tag,
+				Value:   strconv.FormatInt(val, 10),
+				Message: fmt.Sprintf("%d does not fit %s (%d to %d)", val, col.ColumnType, min, max),
+			})
+			if !clamp {
+				continue
+			}
+			if val < min {
+				pf.SetInt(min)
+			} else {
+				pf.SetInt(max)
+			}
+		case reflect.String:
+			if val, ok := truncateColumn(col, pf.String(), tag, clamp, &overflows); ok {
+				pf.SetString(val)
+			}
+		case reflect.Struct:
+			ns, ok := pf.Interface().(sql.NullString)
+			if !ok || !ns.Valid {
+				continue
+			}
+			if val, ok := truncateColumn(col, ns.String, tag, clamp, &overflows); ok {
+				ns.String = val
+				pf.Set(reflect.ValueOf(ns))
+			}
+		}
+	}
+	return overflows
+}
+
+// truncateColumn records value if it is longer than col allows, returning the truncated value when clamp is true
+func truncateColumn(col *ColumnDef, value string, tag string, clamp bool, overflows *[]*ColumnOverflow) (string, bool) {
+	if !col.MaxLength.Valid {
+		return "", false
+	}
+	maxLength := int(col.MaxLength.Int64)
+	length := utf8.RuneCountInString(value)
+	if length <= maxLength {
+		return "", false
+	}
+	*overflows = append(*overflows, &ColumnOverflow{
+		Column:  tag,
+		Value:   value,
+		Message: fmt.Sprintf("length %d does not fit %s", length, col.ColumnType),
+	})
+	if !clamp {
+		return "", false
+	}
+	return string([]rune(value)[:maxLength]), true
+}
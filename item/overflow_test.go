@@ -0,0 +1,94 @@
+package item
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+)
+
+func TestIntRange(t *testing.T) {
+	tests := []struct {
+		dataType   string
+		columnType string
+		min        int64
+		max        int64
+		ok         bool
+	}{
+		{dataType: "tinyint", columnType: "tinyint(3)", min: -128, max: 127, ok: true},
+		{dataType: "tinyint", columnType: "tinyint(3) unsigned", min: 0, max: 255, ok: true},
+		{dataType: "smallint", columnType: "smallint(6)", min: -32768, max: 32767, ok: true},
+		{dataType: "smallint", columnType: "smallint(5) unsigned", min: 0, max: 65535, ok: true},
+		{dataType: "mediumint", columnType: "mediumint(9)", min: -8388608, max: 8388607, ok: true},
+		{dataType: "mediumint", columnType: "mediumint(8) UNSIGNED", min: 0, max: 16777215, ok: true},
+		{dataType: "int", columnType: "int(11)", min: math.MinInt32, max: math.MaxInt32, ok: true},
+		{dataType: "INTEGER", columnType: "int(10) unsigned", min: 0, max: math.MaxUint32, ok: true},
+		{dataType: "bigint", columnType: "bigint(20)", min: math.MinInt64, max: math.MaxInt64, ok: true},
+		//the top half of an unsigned bigint does not fit the int64 fields
+		{dataType: "bigint", columnType: "bigint(20) unsigned", min: 0, max: math.MaxInt64, ok: true},
+		{dataType: "varchar", columnType: "varchar(64)"},
+		{dataType: "float", columnType: "float"},
+	}
+	for _, tt := range tests {
+		col := &ColumnDef{DataType: tt.dataType, ColumnType: tt.columnType}
+		min, max, ok := col.intRange()
+		if ok != tt.ok || min != tt.min || max != tt.max {
+			t.Errorf("%s: got %d to %d (%t), want %d to %d (%t)", tt.columnType, min, max, ok, tt.min, tt.max, tt.ok)
+		}
+	}
+}
+
+func TestOverflows(t *testing.T) {
+	schema := &Schema{columns: map[string]*ColumnDef{
+		"reqlevel": {Name: "reqlevel", DataType: "tinyint", ColumnType: "tinyint(3) unsigned"},
+		"ac":       {Name: "ac", DataType: "smallint", ColumnType: "smallint(6)"},
+		"name":     {Name: "Name", DataType: "varchar", ColumnType: "varchar(8)", MaxLength: sql.NullInt64{Int64: 8, Valid: true}},
+		"unk132":   {Name: "UNK132", DataType: "varchar", ColumnType: "varchar(3)", MaxLength: sql.NullInt64{Int64: 3, Valid: true}},
+		"classes":  {Name: "classes", DataType: "int", ColumnType: "int(11)"},
+	}}
+	tests := []struct {
+		item    EQEmuItem
+		columns []string
+		clamped EQEmuItem
+	}{
+		//every value on its column's boundary fits
+		{item: EQEmuItem{Reqlevel: 255, Ac: -32768, Name: "ünïcödé!", Classes: math.MaxInt32}},
+		{item: EQEmuItem{Reqlevel: 0, Ac: 32767, Classes: math.MinInt32}},
+		{
+			item:    EQEmuItem{Reqlevel: 256, Ac: -32769, Name: "Cloth Cap", Classes: math.MaxInt32 + 1},
+			columns: []string{"ac", "classes", "Name", "reqlevel"},
+			clamped: EQEmuItem{Reqlevel: 255, Ac: -32768, Name: "Cloth Ca", Classes: math.MaxInt32},
+		},
+		{
+			item:    EQEmuItem{Reqlevel: -1, Ac: 32768, Classes: math.MinInt32 - 1, UNK132: sql.NullString{String: "abcd", Valid: true}},
+			columns: []string{"ac", "classes", "reqlevel", "UNK132"},
+			clamped: EQEmuItem{Reqlevel: 0, Ac: 32767, Classes: math.MinInt32, UNK132: sql.NullString{String: "abc", Valid: true}},
+		},
+	}
+	for i, tt := range tests {
+		it := tt.item
+		overflows := schema.Overflows(&it, false)
+		got := map[string]bool{}
+		for _, overflow := range overflows {
+			got[overflow.Column] = true
+		}
+		if len(got) != len(tt.columns) {
+			t.Errorf("test %d: got overflows %v, want %v", i, got, tt.columns)
+		}
+		for _, column := range tt.columns {
+			if !got[column] {
+				t.Errorf("test %d: %s did not overflow", i, column)
+			}
+		}
+		if it != tt.item {
+			t.Errorf("test %d: reporting overflows changed the item", i)
+		}
+		if len(tt.columns) == 0 {
+			continue
+		}
+		schema.Overflows(&it, true)
+		if it.Reqlevel != tt.clamped.Reqlevel || it.Ac != tt.clamped.Ac || it.Classes != tt.clamped.Classes || it.Name != tt.clamped.Name || it.UNK132 != tt.clamped.UNK132 {
+			t.Errorf("test %d: clamped to %d %d %d %q %q, want %d %d %d %q %q", i, it.Reqlevel, it.Ac, it.Classes, it.Name, it.UNK132.String,
+				tt.clamped.Reqlevel, tt.clamped.Ac, tt.clamped.Classes, tt.clamped.Name, tt.clamped.UNK132.String)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package item
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	tagIndexOnce sync.Once
+	// tagIndexes maps a tag key, then a tag name, to the index of the EQEmuItem field with that tag
+	tagIndexes map[string]map[string]int
+)
+
+// fieldIndex returns the index of the EQEmuItem field whose tagKey tag is tagName
+func fieldIndex(tagKey string, tagName string) (int, bool) {
+	tagIndexOnce.Do(func() {
+		tagIndexes = map[string]map[string]int{
+			"db":     {},
+			"sodaeq": {},
+		}
+		st := reflect.TypeOf(EQEmuItem{})
+		for i := 0; i < st.NumField(); i++ {
+			for tagKey, indexes := range tagIndexes {
+				tag, ok := st.Field(i).Tag.Lookup(tagKey)
+				if !ok {
+					continue
+				}
+				//the first field with a tag wins, the same as a scan of the struct would
+				if _, ok := indexes[tag]; !ok {
+					indexes[tag] = i
+				}
+			}
+		}
+	})
+	index, ok := tagIndexes[tagKey][tagName]
+	return index, ok
+}
+
+// Plan decodes records that share a header. The field each column sets is looked up once
+// when the plan is built, so decoding a record only does work for the columns it has
+type Plan struct {
+	mapping  *Mapping
+	columns  []*planColumn
+	defaults []*planColumn
+	// err is returned for every record, e.g. when a header has no matching field
+	err error
+}
+
+// planColumn is a header column, or a mapping default, and the field it sets
+type planColumn struct {
+	header  string
+	index   int
+	fm      *FieldMapping
+	decimal string
+	isSkip  bool
+}
+
+// NewPlan returns a plan for records with header, reading headers through mapping if it is not nil
+func NewPlan(mapping *Mapping, header []string) *Plan {
+	return newPlan("sodaeq", mapping, header)
+}
+
+// newPlan returns a plan for a header of tagKey names. Mapping fields only apply to sodaeq headers
+func newPlan(tagKey string, mapping *Mapping, header []string) *Plan {
+	p := &Plan{mapping: mapping}
+	decimal := DecimalTruncate
+	if mapping != nil && mapping.Decimal != "" {
+		decimal = mapping.Decimal
+	}
+
+	found := map[string]bool{}
+	for _, field := range header {
+		found[field] = true
+		col := &planColumn{header: field, decimal: decimal}
+		p.columns = append(p.columns, col)
+		if tagKey == "sodaeq" && mapping != nil {
+			col.fm = mapping.Fields[field]
+		}
+		if col.fm != nil && col.fm.Skip {
+			col.isSkip = true
+			continue
+		}
+		colKey, colName := tagKey, field
+		if col.fm != nil {
+			colKey, colName = col.fm.target(field)
+			if col.fm.Decimal != "" {
+				col.decimal = col.fm.Decimal
+			}
+		}
+		index, ok := fieldIndex(colKey, colName)
+		if !ok {
+			if p.err == nil {
+				p.err = errors.Wrapf(fmt.Errorf("no %s tag found", colKey), "field %s", field)
+			}
+			continue
+		}
+		col.index = index
+	}
+
+	if tagKey != "sodaeq" || mapping == nil {
+		return p
+	}
+	//defaults for mapped headers missing from the file, in a stable order
+	names := []string{}
+	for field, fm := range mapping.Fields {
+		if found[field] || fm.Skip || fm.Default == nil {
+			continue
+		}
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	for _, field := range names {
+		fm := mapping.Fields[field]
+		col := &planColumn{header: field, fm: fm, decimal: decimal}
+		if fm.Decimal != "" {
+			col.decimal = fm.Decimal
+		}
+		index, ok := fieldIndex(fm.target(field))
+		if !ok {
+			if p.err == nil {
+				p.err = fmt.Errorf("default %s: no field found", field)
+			}
+			continue
+		}
+		col.index = index
+		p.defaults = append(p.defaults, col)
+	}
+	return p
+}
+
+// Decode constructs an item from a record. Precision lost to the decimal policy is added to the mapping's Losses
+func (p *Plan) Decode(record []string) (*EQEmuItem, error) {
+	item, losses, err := p.decode(record)
+	if err != nil {
+		return nil, err
+	}
+	p.mapping.addLosses(losses)
+	return item, nil
+}
+
+// decode constructs an item from a record without touching the mapping, so records can be decoded concurrently
+func (p *Plan) decode(record []string) (*EQEmuItem, []*PrecisionLoss, error) {
+	if len(p.columns) != len(record) {
+		return nil, nil, fmt.Errorf("header count (%d) does not match record count (%d)", len(p.columns), len(record))
+	}
+	if p.err != nil {
+		return nil, nil, p.err
+	}
+
+	item := new(EQEmuItem)
+	s := reflect.ValueOf(item).Elem()
+	var losses []*PrecisionLoss
+	for i, col := range p.columns {
+		if col.isSkip {
+			continue
+		}
+		isLossy, err := col.set(s, record[i])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "field %s", col.header)
+		}
+		if isLossy {
+			losses = append(losses, &PrecisionLoss{Field: col.header, Value: record[i]})
+		}
+	}
+	for _, col := range p.defaults {
+		if _, err := col.set(s, ""); err != nil {
+			return nil, nil, errors.Wrapf(err, "default %s", col.header)
+		}
+	}
+	for _, loss := range losses {
+		loss.ItemID = item.ID
+	}
+	return item, losses, nil
+}
+
+// addLosses appends losses to the mapping's Losses, if there is a mapping
+func (m *Mapping) addLosses(losses []*PrecisionLoss) {
+	if m == nil || len(losses) == 0 {
+		return
+	}
+	m.Losses = append(m.Losses, losses...)
+}
+
+// set applies the column's mapping to value and stores it in the column's field of s
+func (col *planColumn) set(s reflect.Value, value string) (bool, error) {
+	if col.fm != nil {
+		var err error
+		value, err = col.fm.value(value)
+		if err != nil {
+			return false, err
+		}
+	}
+	return setValue(s.Field(col.index), col.header, value, col.decimal)
+}
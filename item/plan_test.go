@@ -0,0 +1,79 @@
+package item
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// benchRecord returns a full sodaeq header, one column per field, and a record for it
+func benchRecord() ([]string, []string) {
+	it := &EQEmuItem{ID: 1001, Name: "Cloth Cap", Classes: 65535, Races: 65535, Reqlevel: 1, Price: 4, Sellrate: 1}
+	return TagHeader("sodaeq"), it.TagRecord("sodaeq")
+}
+
+// scanItem decodes a record the way items were decoded before plans, scanning the struct's tags for every column
+func scanItem(header []string, record []string) (*EQEmuItem, error) {
+	item := new(EQEmuItem)
+	st := reflect.TypeOf(*item)
+	s := reflect.ValueOf(item).Elem()
+	for i, name := range header {
+		found := false
+		for j := 0; j < st.NumField(); j++ {
+			tag, ok := st.Field(j).Tag.Lookup("sodaeq")
+			if !ok || tag != name {
+				continue
+			}
+			if _, err := setValue(s.Field(j), name, record[i], DecimalTruncate); err != nil {
+				return nil, errors.Wrapf(err, "field %s", name)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("field %s: no sodaeq tag found", name)
+		}
+	}
+	return item, nil
+}
+
+func TestPlanDecodeMatchesScan(t *testing.T) {
+	header, record := benchRecord()
+	want, err := scanItem(header, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewPlan(nil, header).Decode(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("plan decoded %+v, scan decoded %+v", got, want)
+	}
+}
+
+func BenchmarkPlanDecode(b *testing.B) {
+	header, record := benchRecord()
+	plan := NewPlan(nil, header)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := plan.Decode(record); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkScanDecode is the baseline BenchmarkPlanDecode is compared to
+func BenchmarkScanDecode(b *testing.B) {
+	header, record := benchRecord()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := scanItem(header, record); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
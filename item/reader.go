@@ -0,0 +1,215 @@
+package item
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ErrorMode is what a Reader does with a line that fails to parse
+type ErrorMode int
+
+const (
+	// SkipOnError skips malformed lines, reporting each to OnSkip
+	SkipOnError ErrorMode = iota
+	// FailFast stops reading at the first malformed line
+	FailFast
+)
+
+// Reader reads items from an items file one at a time, e.g.
+//
+//	r, err := item.NewReader(ctx, f, "sodeq", nil, item.SkipOnError)
+//	defer r.Close()
+//	for r.Next() {
+//		fmt.Println(r.Line(), r.Item().Name)
+//	}
+//	err = r.Err()
+type Reader struct {
+	// OnSkip is called with every line skipped in SkipOnError mode
+	OnSkip func(line int, err error)
+	// Workers is how many goroutines decode records while another reads them, set before the first Next.
+	// Items are still returned in input order. 0 reads and decodes on the goroutine calling Next.
+	// The goroutines run until the input is done, so call Close when stopping early
+	Workers int
+
+	ctx     context.Context
+	dec     recordDecoder
+	mode    ErrorMode
+	item    *EQEmuItem
+	losses  []*PrecisionLoss
+	line    int
+	skipped int
+	isDone  bool
+	err     error
+	results chan *decodeResult
+	quit    chan struct{}
+}
+
+// decodeResult is a record read by a Reader, and the item its workers decoded from it
+type decodeResult struct {
+	line   int
+	record interface{}
+	item   *EQEmuItem
+	losses []*PrecisionLoss
+	err    error
+	//done is closed once the record is decoded
+	done chan struct{}
+}
+
+// NewReader returns a Reader of format (see NewDecoder) that stops once ctx is done
+func NewReader(ctx context.Context, r io.Reader, format string, mapping *Mapping, mode ErrorMode) (*Reader, error) {
+	dec, err := NewDecoder(r, format, mapping)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		ctx:  ctx,
+		dec:  dec.(recordDecoder),
+		mode: mode,
+	}, nil
+}
+
+// Next reads the next item, returning false when the input is done or reading stopped early.
+// Err reports why it stopped early
+func (r *Reader) Next() bool {
+	r.item = nil
+	r.losses = nil
+	if r.Workers > 0 && r.results == nil && !r.isDone {
+		r.start()
+	}
+	for !r.isDone {
+		if err := r.ctx.Err(); err != nil {
+			r.stop(err)
+			return false
+		}
+		res := r.read()
+		r.line = res.line
+		if res.err == io.EOF {
+			r.stop(nil)
+			return false
+		}
+		if res.err != nil {
+			if !IsLineError(res.err) || r.mode == FailFast {
+				r.stop(errors.Wrapf(res.err, "line %d", r.line))
+				return false
+			}
+			r.skipped++
+			if r.OnSkip != nil {
+				r.OnSkip(r.line, res.err)
+			}
+			continue
+		}
+		r.item = res.item
+		r.losses = res.losses
+		return true
+	}
+	return false
+}
+
+// read returns the next record and its item, waiting on the workers if they are running
+func (r *Reader) read() *decodeResult {
+	if r.results == nil {
+		res := &decodeResult{}
+		res.record, res.err = r.dec.next()
+		res.line = r.dec.Line()
+		if res.err == nil {
+			res.item, res.losses, res.err = r.dec.decode(res.record)
+		}
+		return res
+	}
+	res, ok := <-r.results
+	if !ok {
+		//the read goroutine only gives up early when ctx is done
+		return &decodeResult{line: r.line, err: r.ctx.Err()}
+	}
+	<-res.done
+	return res
+}
+
+// start reads records on one goroutine and decodes them on Workers more. Results are queued in
+// input order, so Next only has to wait for the record at the front of the queue
+func (r *Reader) start() {
+	r.results = make(chan *decodeResult, r.Workers*4)
+	quit := make(chan struct{})
+	r.quit = quit
+	records := make(chan *decodeResult, r.Workers*4)
+	for i := 0; i < r.Workers; i++ {
+		go func() {
+			for res := range records {
+				res.item, res.losses, res.err = r.dec.decode(res.record)
+				close(res.done)
+			}
+		}()
+	}
+	go func() {
+		defer close(r.results)
+		defer close(records)
+		for {
+			res := &decodeResult{done: make(chan struct{})}
+			res.record, res.err = r.dec.next()
+			res.line = r.dec.Line()
+			if res.err != nil {
+				close(res.done)
+			}
+			select {
+			case r.results <- res:
+			case <-quit:
+				return
+			case <-r.ctx.Done():
+				return
+			}
+			if res.err == nil {
+				records <- res
+				continue
+			}
+			if !IsLineError(res.err) || r.mode == FailFast {
+				return
+			}
+		}
+	}()
+}
+
+func (r *Reader) stop(err error) {
+	r.err = err
+	r.isDone = true
+	if r.quit != nil {
+		close(r.quit)
+		r.quit = nil
+	}
+}
+
+// Close stops reading, letting any Workers goroutines exit. A goroutine blocked reading the underlying
+// io.Reader exits once that read returns. Close does not close the io.Reader, and Next returns false after it
+func (r *Reader) Close() error {
+	if !r.isDone {
+		r.stop(nil)
+	}
+	return nil
+}
+
+// Item returns the item read by the last call to Next
+func (r *Reader) Item() *EQEmuItem {
+	return r.item
+}
+
+// Losses returns the precision lost decoding the item read by the last call to Next. They are not added
+// to the mapping's Losses, so a caller filtering items only records losses for the items it keeps
+func (r *Reader) Losses() []*PrecisionLoss {
+	return r.losses
+}
+
+// Line returns the line number of the last line read
+func (r *Reader) Line() int {
+	return r.line
+}
+
+// Skipped returns how many malformed lines have been skipped
+func (r *Reader) Skipped() int {
+	return r.skipped
+}
+
+// Err returns the error that stopped reading early, or nil if the whole input was read
+func (r *Reader) Err() error {
+	return r.err
+}
@@ -0,0 +1,155 @@
+package item
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderCloseStopsWorkers(t *testing.T) {
+	header, record := benchRecord()
+	lines := []string{strings.Join(header, "|")}
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, strings.Join(record, "|"))
+	}
+	before := runtime.NumGoroutine()
+
+	r, err := NewReader(context.Background(), strings.NewReader(strings.Join(lines, "\n")), "sodeq", nil, FailFast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Workers = 4
+	if !r.Next() {
+		t.Fatalf("no item read: %v", r.Err())
+	}
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if r.Next() {
+		t.Errorf("Next returned an item after Close")
+	}
+
+	//the goroutines exit asynchronously once quit is closed
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%d goroutines still running after Close, %d before reading", n, before)
+	}
+}
+
+func TestReaderWorkersKeepOrder(t *testing.T) {
+	header, record := benchRecord()
+	idIndex := -1
+	for i, name := range header {
+		if name == "id" {
+			idIndex = i
+		}
+	}
+	lines := []string{strings.Join(header, "|")}
+	for i := 0; i < 500; i++ {
+		fields := append([]string{}, record...)
+		fields[idIndex] = strings.Repeat("1", 1+i%9)
+		lines = append(lines, strings.Join(fields, "|"))
+		if i%100 == 0 {
+			lines = append(lines, "bad|line")
+		}
+	}
+	input := strings.Join(lines, "\n")
+
+	read := func(workers int) ([]int64, []int) {
+		r, err := NewReader(context.Background(), strings.NewReader(input), "sodeq", nil, SkipOnError)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		r.Workers = workers
+		skipped := []int{}
+		r.OnSkip = func(line int, err error) { skipped = append(skipped, line) }
+		ids := []int64{}
+		for r.Next() {
+			ids = append(ids, r.Item().ID)
+		}
+		if err = r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Skipped() != len(skipped) {
+			t.Errorf("%d workers: Skipped is %d, OnSkip was called %d times", workers, r.Skipped(), len(skipped))
+		}
+		return ids, skipped
+	}
+	wantIDs, wantSkipped := read(0)
+	gotIDs, gotSkipped := read(8)
+	if len(wantIDs) != 500 || len(wantSkipped) != 5 {
+		t.Fatalf("serial read %d items and skipped %d lines, want 500 and 5", len(wantIDs), len(wantSkipped))
+	}
+	for i := range wantIDs {
+		if gotIDs[i] != wantIDs[i] {
+			t.Fatalf("item %d: got id %d, want %d", i, gotIDs[i], wantIDs[i])
+		}
+	}
+	for i := range wantSkipped {
+		if gotSkipped[i] != wantSkipped[i] {
+			t.Fatalf("skip %d: got line %d, want %d", i, gotSkipped[i], wantSkipped[i])
+		}
+	}
+}
+
+func TestReaderHeaderErrorIsFatal(t *testing.T) {
+	header, record := benchRecord()
+	input := strings.Join(append(header, "nosuchcolumn"), "|") + "\n" + strings.Join(append(record, "1"), "|") + "\n"
+	for _, workers := range []int{0, 4} {
+		r, err := NewReader(context.Background(), strings.NewReader(input), "sodeq", nil, SkipOnError)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Workers = workers
+		skipped := 0
+		r.OnSkip = func(line int, err error) { skipped++ }
+		if r.Next() {
+			t.Errorf("%d workers: read an item with a bad header", workers)
+		}
+		r.Close()
+		want := "line 1: field nosuchcolumn: no sodaeq tag found"
+		if err = r.Err(); err == nil || err.Error() != want {
+			t.Errorf("%d workers: got error %v, want %s", workers, err, want)
+		}
+		if skipped != 0 {
+			t.Errorf("%d workers: skipped %d lines, want the header error to stop reading", workers, skipped)
+		}
+	}
+}
+
+func TestReaderLosses(t *testing.T) {
+	input := "id|price\n1|4.5\n2|3\n3|7.25\n"
+	for _, workers := range []int{0, 4} {
+		mapping := &Mapping{Decimal: DecimalTruncate}
+		r, err := NewReader(context.Background(), strings.NewReader(input), "sodeq", mapping, FailFast)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Workers = workers
+		got := []string{}
+		for r.Next() {
+			for _, loss := range r.Losses() {
+				if loss.ItemID != r.Item().ID {
+					t.Errorf("%d workers: loss for item %d returned with item %d", workers, loss.ItemID, r.Item().ID)
+				}
+				got = append(got, loss.Field+"="+loss.Value)
+			}
+		}
+		r.Close()
+		if err = r.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(got, ",") != "price=4.5,price=7.25" {
+			t.Errorf("%d workers: got losses %v, want price=4.5,price=7.25", workers, got)
+		}
+		//the caller decides which items' losses to keep
+		if len(mapping.Losses) != 0 {
+			t.Errorf("%d workers: reader added %d losses to the mapping", workers, len(mapping.Losses))
+		}
+	}
+}
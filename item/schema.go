@@ -0,0 +1,119 @@
+package item
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Schema is the set of columns that exist on the target items table.
+// A nil schema allows every db tag except OptionalColumns, matching a stock eqemu items table
+type Schema struct {
+	columns map[string]*ColumnDef
+	// renames maps db tags to the column name this table uses for them
+	Renames map[string]string
+	dialect Dialect
+}
+
+// ColumnDef is the definition of an items table column
+type ColumnDef struct {
+	Name       string        `db:"name"`
+	DataType   string        `db:"data_type"`
+	ColumnType string        `db:"column_type"`
+	MaxLength  sql.NullInt64 `db:"max_length"`
+}
+
+// LoadSchema reads the items table definition from information_schema, or from sqlite's table info
+func LoadSchema(db *sqlx.DB, dialect Dialect) (*Schema, error) {
+	if dialect == DialectSQLite {
+		return loadSQLiteSchema(db)
+	}
+	columns := []*ColumnDef{}
+	err := db.Select(&columns, "SELECT COLUMN_NAME AS name, DATA_TYPE AS data_type, COLUMN_TYPE AS column_type, CHARACTER_MAXIMUM_LENGTH AS max_length FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'items'")
+	if err != nil {
+		return nil, errors.Wrap(err, "select columns")
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("items table not found")
+	}
+	schema := &Schema{
+		columns: make(map[string]*ColumnDef, len(columns)),
+		dialect: DialectMySQL,
+	}
+	for _, column := range columns {
+		schema.columns[strings.ToLower(column.Name)] = column
+	}
+	return schema, nil
+}
+
+// Dialect returns the dialect of the database the items table is in
+func (schema *Schema) Dialect() Dialect {
+	if schema == nil || schema.dialect == "" {
+		return DialectMySQL
+	}
+	return schema.dialect
+}
+
+// ColumnName returns the items table column a db tag is stored in
+func (schema *Schema) ColumnName(tag string) string {
+	if schema == nil {
+		return tag
+	}
+	if column, ok := schema.Renames[tag]; ok {
+		return column
+	}
+	return tag
+}
+
+// Has returns true if the column for a db tag exists on the items table
+func (schema *Schema) Has(tag string) bool {
+	if schema == nil {
+		return !OptionalColumns[tag]
+	}
+	return schema.columns[strings.ToLower(schema.ColumnName(tag))] != nil
+}
+
+// Column returns the column definition for a db tag, or nil if it does not exist or the schema is unknown
+func (schema *Schema) Column(tag string) *ColumnDef {
+	if schema == nil {
+		return nil
+	}
+	return schema.columns[strings.ToLower(schema.ColumnName(tag))]
+}
+
+// Mismatches returns db tags missing from the table, optional db tags missing from the table,
+// and table columns with no db tag
+func (schema *Schema) Mismatches() (tagOnly []string, optionalOnly []string, tableOnly []string) {
+	if schema == nil {
+		return nil, nil, nil
+	}
+	tags := map[string]bool{}
+	st := reflect.TypeOf(EQEmuItem{})
+	for i := 0; i < st.NumField(); i++ {
+		tag, ok := st.Field(i).Tag.Lookup("db")
+		if !ok {
+			continue
+		}
+		tags[strings.ToLower(schema.ColumnName(tag))] = true
+		if schema.Has(tag) {
+			continue
+		}
+		if OptionalColumns[tag] {
+			optionalOnly = append(optionalOnly, tag)
+			continue
+		}
+		tagOnly = append(tagOnly, tag)
+	}
+	for column := range schema.columns {
+		if !tags[column] {
+			tableOnly = append(tableOnly, column)
+		}
+	}
+	sort.Strings(tableOnly)
+	return tagOnly, optionalOnly, tableOnly
+}
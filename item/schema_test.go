@@ -0,0 +1,39 @@
+package item
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNilSchemaSkipsOptionalColumns(t *testing.T) {
+	it := &EQEmuItem{ID: 1}
+	queries := map[string]string{
+		"insert": it.InsertQuery(nil),
+		"update": it.UpdateQuery(nil),
+		"select": it.SelectQuery(nil),
+	}
+	for name, query := range queries {
+		for tag := range OptionalColumns {
+			if strings.Contains(query, "`"+tag+"`") {
+				t.Errorf("%s query has optional column %s", name, tag)
+			}
+		}
+		if !strings.Contains(query, "`Name`") {
+			t.Errorf("%s query is missing Name: %s", name, query)
+		}
+	}
+}
+
+func TestOptionalColumns(t *testing.T) {
+	want := []string{"augstricthidden", "blessingeffect", "blessingname", "collectible", "collectversion", "convertid", "convertitem", "convertname",
+		"evolvl", "foodduration", "freestorage", "herosforgemodel2", "marketplace", "materialunk1", "nodestroy", "noground", "nonpc", "nozone",
+		"placeablenpcname", "prestige", "prockunk1", "skillmodextra"}
+	if len(OptionalColumns) != len(want) {
+		t.Errorf("got %d optional columns, want %d", len(OptionalColumns), len(want))
+	}
+	for _, tag := range want {
+		if !OptionalColumns[tag] {
+			t.Errorf("%s is not optional", tag)
+		}
+	}
+}
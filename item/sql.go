@@ -0,0 +1,164 @@
+package item
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// TimeFormat is how mysql datetime values are written
+const TimeFormat = "2006-01-02 15:04:05"
+
+// NullTime is a datetime that may be NULL. Unlike sql.NullTime it scans the text mysql returns
+// without parseTime, which the connection leaves off so columns such as updated keep scanning as TimeFormat strings
+type NullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Scan implements sql.Scanner for time values and TimeFormat text
+func (nt *NullTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*nt = NullTime{}
+		return nil
+	case time.Time:
+		*nt = NullTime{Time: v, Valid: true}
+		return nil
+	case []byte:
+		return nt.parse(string(v))
+	case string:
+		return nt.parse(v)
+	}
+	return fmt.Errorf("unsupported datetime type %T", value)
+}
+
+func (nt *NullTime) parse(value string) error {
+	val, err := time.Parse(TimeFormat, value)
+	if err != nil {
+		return err
+	}
+	*nt = NullTime{Time: val, Valid: true}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (nt NullTime) Value() (driver.Value, error) {
+	if !nt.Valid {
+		return nil, nil
+	}
+	return nt.Time, nil
+}
+
+// Dialect is the flavor of sql spoken by the target database
+type Dialect string
+
+const (
+	// DialectMySQL is mysql or mariadb, the databases eqemu servers run on
+	DialectMySQL Dialect = "mysql"
+	// DialectSQLite is a standalone sqlite database
+	DialectSQLite Dialect = "sqlite"
+)
+
+// Quote returns value as an escaped string literal
+func (d Dialect) Quote(value string) string {
+	if d == DialectSQLite {
+		//sqlite has no backslash escapes, only doubled quotes. A NUL byte would cut the statement short, so it is joined in with char(0)
+		quoted := "'" + strings.Replace(value, "'", "''", -1) + "'"
+		return strings.Replace(quoted, "\x00", "' || char(0) || '", -1)
+	}
+	return sqlString(value)
+}
+
+// RenderQuery binds arg to a named query, escaping each value as a literal of dialect
+func RenderQuery(query string, arg interface{}, dialect Dialect) (string, error) {
+	bound, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return "", errors.Wrap(err, "named")
+	}
+
+	parts := strings.Split(bound, "?")
+	if len(parts) != len(args)+1 {
+		return "", fmt.Errorf("placeholder count (%d) does not match arg count (%d)", len(parts)-1, len(args))
+	}
+
+	out := strings.Builder{}
+	for i, part := range parts {
+		out.WriteString(part)
+		if i == len(args) {
+			break
+		}
+		literal, err := Literal(args[i], dialect)
+		if err != nil {
+			return "", errors.Wrapf(err, "arg %d", i)
+		}
+		out.WriteString(literal)
+	}
+	return out.String(), nil
+}
+
+// Literal returns value as an escaped literal of dialect
+func Literal(value interface{}, dialect Dialect) (string, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		value = val
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case string:
+		return dialect.Quote(v), nil
+	case []byte:
+		return dialect.Quote(string(v)), nil
+	case time.Time:
+		return dialect.Quote(v.Format(TimeFormat)), nil
+	}
+	return "", fmt.Errorf("unsupported type %T", value)
+}
+
+// sqlString quotes and escapes a string the same way mysql_real_escape_string does
+func sqlString(value string) string {
+	out := strings.Builder{}
+	out.WriteByte('\'')
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch c {
+		case 0:
+			out.WriteString(`\0`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\r':
+			out.WriteString(`\r`)
+		case '\x1a':
+			out.WriteString(`\Z`)
+		case '\'':
+			out.WriteString(`\'`)
+		case '"':
+			out.WriteString(`\"`)
+		case '\\':
+			out.WriteString(`\\`)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	out.WriteByte('\'')
+	return out.String()
+}
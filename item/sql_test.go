@@ -0,0 +1,123 @@
+package item
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestNullTimeScan(t *testing.T) {
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value interface{}
+		want  NullTime
+		err   bool
+	}{
+		//mysql returns datetime and text columns as bytes without parseTime
+		{value: []byte("2020-01-02 03:04:05"), want: NullTime{Time: want, Valid: true}},
+		{value: "2020-01-02 03:04:05", want: NullTime{Time: want, Valid: true}},
+		{value: want, want: NullTime{Time: want, Valid: true}},
+		{value: nil, want: NullTime{}},
+		{value: []byte("yesterday"), err: true},
+		{value: int64(5), err: true},
+	}
+	for _, tt := range tests {
+		nt := NullTime{Time: time.Now(), Valid: true}
+		err := nt.Scan(tt.value)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%v: scanned %v, want an error", tt.value, nt)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", tt.value, err)
+			continue
+		}
+		if nt != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.value, nt, tt.want)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		value  string
+		mysql  string
+		sqlite string
+	}{
+		{value: "Cloth Cap", mysql: `'Cloth Cap'`, sqlite: `'Cloth Cap'`},
+		{value: "", mysql: `''`, sqlite: `''`},
+		{value: "Tunare's Gift", mysql: `'Tunare\'s Gift'`, sqlite: `'Tunare''s Gift'`},
+		{value: `say "hail"`, mysql: `'say \"hail\"'`, sqlite: `'say "hail"'`},
+		{value: `C:\eq\`, mysql: `'C:\\eq\\'`, sqlite: `'C:\eq\'`},
+		//a backslash before a quote must not end the literal early
+		{value: `\'; DROP TABLE items; --`, mysql: `'\\\'; DROP TABLE items; --'`, sqlite: `'\''; DROP TABLE items; --'`},
+		{value: "a\x00b", mysql: `'a\0b'`, sqlite: `'a' || char(0) || 'b'`},
+		{value: "line\nnext\r", mysql: `'line\nnext\r'`, sqlite: "'line\nnext\r'"},
+		{value: "eof\x1a", mysql: `'eof\Z'`, sqlite: "'eof\x1a'"},
+		{value: "ÿ ünïcödé", mysql: `'ÿ ünïcödé'`, sqlite: `'ÿ ünïcödé'`},
+	}
+	for _, tt := range tests {
+		if got := DialectMySQL.Quote(tt.value); got != tt.mysql {
+			t.Errorf("mysql %q: got %s, want %s", tt.value, got, tt.mysql)
+		}
+		if got := DialectSQLite.Quote(tt.value); got != tt.sqlite {
+			t.Errorf("sqlite %q: got %s, want %s", tt.value, got, tt.sqlite)
+		}
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value  interface{}
+		mysql  string
+		sqlite string
+	}{
+		{value: nil, mysql: "NULL", sqlite: "NULL"},
+		{value: sql.NullString{}, mysql: "NULL", sqlite: "NULL"},
+		{value: sql.NullString{String: "it's", Valid: true}, mysql: `'it\'s'`, sqlite: `'it''s'`},
+		{value: NullTime{}, mysql: "NULL", sqlite: "NULL"},
+		{value: NullTime{Time: when, Valid: true}, mysql: "'2020-01-02 03:04:05'", sqlite: "'2020-01-02 03:04:05'"},
+		{value: when, mysql: "'2020-01-02 03:04:05'", sqlite: "'2020-01-02 03:04:05'"},
+		{value: int64(-42), mysql: "-42", sqlite: "-42"},
+		{value: 1.5, mysql: "1.5", sqlite: "1.5"},
+		{value: true, mysql: "1", sqlite: "1"},
+		{value: []byte("a'b"), mysql: `'a\'b'`, sqlite: `'a''b'`},
+	}
+	for _, tt := range tests {
+		for dialect, want := range map[Dialect]string{DialectMySQL: tt.mysql, DialectSQLite: tt.sqlite} {
+			got, err := Literal(tt.value, dialect)
+			if err != nil {
+				t.Errorf("%s %v: %v", dialect, tt.value, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("%s %v: got %s, want %s", dialect, tt.value, got, want)
+			}
+		}
+	}
+	if _, err := Literal(struct{}{}, DialectMySQL); err == nil {
+		t.Errorf("unsupported type rendered without an error")
+	}
+}
+
+func TestRenderQuery(t *testing.T) {
+	arg := &EQEmuItem{ID: 1001, Name: "What? It's \\ mine", Serialized: NullTime{Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true}}
+	query := "UPDATE items SET `Name` = :Name, `serialized` = :serialized, `serialization` = :serialization WHERE `id` = :id"
+	tests := map[Dialect]string{
+		DialectMySQL:  "UPDATE items SET `Name` = 'What? It\\'s \\\\ mine', `serialized` = '2020-01-02 03:04:05', `serialization` = NULL WHERE `id` = 1001",
+		DialectSQLite: "UPDATE items SET `Name` = 'What? It''s \\ mine', `serialized` = '2020-01-02 03:04:05', `serialization` = NULL WHERE `id` = 1001",
+	}
+	for dialect, want := range tests {
+		got, err := RenderQuery(query, arg, dialect)
+		if err != nil {
+			t.Errorf("%s: %v", dialect, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %s, want %s", dialect, got, want)
+		}
+	}
+}
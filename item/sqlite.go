@@ -0,0 +1,77 @@
+package item
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// CreateSQLiteQuery returns a CREATE TABLE statement for a sqlite items table with a column per db tag
+func (item *EQEmuItem) CreateSQLiteQuery(renames map[string]string) string {
+	schema := &Schema{Renames: renames}
+	fields := []string{}
+	st := reflect.TypeOf(*item)
+
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		tag, ok := field.Tag.Lookup("db")
+		if !ok {
+			continue
+		}
+		def := ""
+		switch field.Type {
+		case reflect.TypeOf(sql.NullString{}):
+			def = "TEXT"
+		case reflect.TypeOf(NullTime{}):
+			//the sqlite driver only scans DATETIME columns into time values
+			def = "DATETIME"
+		default:
+			switch field.Type.Kind() {
+			case reflect.Int64:
+				def = "INTEGER NOT NULL DEFAULT 0"
+			case reflect.Float64:
+				def = "REAL NOT NULL DEFAULT 0"
+			case reflect.String:
+				def = "TEXT NOT NULL DEFAULT ''"
+			}
+		}
+		if tag == "id" {
+			def = "INTEGER PRIMARY KEY"
+		}
+		fields = append(fields, fmt.Sprintf("`%s` %s", schema.ColumnName(tag), def))
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS items (\n%s\n);", strings.Join(fields, ",\n"))
+}
+
+// loadSQLiteSchema reads the items table definition from a sqlite database
+func loadSQLiteSchema(db *sqlx.DB) (*Schema, error) {
+	columns := []*ColumnDef{}
+	err := db.Select(&columns, "SELECT name, type AS column_type FROM pragma_table_info('items')")
+	if err != nil {
+		return nil, errors.Wrap(err, "select columns")
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("items table not found")
+	}
+	schema := &Schema{
+		columns: make(map[string]*ColumnDef, len(columns)),
+		dialect: DialectSQLite,
+	}
+	for _, column := range columns {
+		column.ColumnType = strings.ToLower(column.ColumnType)
+		column.DataType = column.ColumnType
+		if i := strings.Index(column.DataType, "("); i >= 0 {
+			column.DataType = column.DataType[:i]
+		}
+		//sqlite stores every integer in up to 8 bytes, whatever width the column was declared with
+		if strings.Contains(column.DataType, "int") {
+			column.DataType = "bigint"
+		}
+		schema.columns[strings.ToLower(column.Name)] = column
+	}
+	return schema, nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xackery/eqitem/item"
+)
+
+// reportLosses logs every field and item id where precision was lost, grouped by field
+func reportLosses(losses []*item.PrecisionLoss) {
+	fields := map[string][]string{}
+	for _, loss := range losses {
+		fields[loss.Field] = append(fields[loss.Field], fmt.Sprintf("%d (%s)", loss.ItemID, loss.Value))
+	}
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		log.Warn().Msgf("field %s lost precision on %d items: %s", name, len(fields[name]), strings.Join(fields[name], ", "))
+	}
+}
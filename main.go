@@ -1,11 +1,11 @@
 package main
 
 import (
-	"database/sql"
-	"encoding/csv"
+	"context"
+	"flag"
 	"fmt"
-	"io"
 	"os"
+	"os/signal"
 	"runtime"
 	"strconv"
 	"strings"
@@ -13,12 +13,14 @@ import (
 
 	//mysql db
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/jmoiron/sqlx"
 	"github.com/mattn/go-colorable"
+	"github.com/xackery/eqitem/item"
+
+	//sqlite db
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"github.com/xackery/eqemuconfig"
 )
 
 var (
@@ -29,10 +31,10 @@ var (
 func main() {
 	start := time.Now()
 
-	//logger prep
-	output := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: "2006-01-02 15:04:05"}
+	//logger prep, logs go to stderr so diff, validate and export reports on stdout stay parseable
+	output := zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: "2006-01-02 15:04:05"}
 	if runtime.GOOS == "windows" {
-		output = zerolog.ConsoleWriter{Out: colorable.NewColorableStdout()}
+		output = zerolog.ConsoleWriter{Out: colorable.NewColorableStderr()}
 	}
 	output.FormatLevel = func(i interface{}) string {
 		return strings.ToUpper(fmt.Sprintf("%3s", i))
@@ -55,42 +57,157 @@ func main() {
 		log.Error().Err(err).Msg("failed")
 	}
 	log.Info().Msgf("completed in %0.1f seconds", time.Since(start).Seconds())
+	if err != nil {
+		os.Exit(1)
+	}
 }
 
 func run() error {
-	if len(os.Args) < 2 {
-		fmt.Println("usage: itemimport items.txt [itemid]")
-		os.Exit(1)
+	command := "import"
+	args := os.Args[1:]
+	if len(args) > 0 && (args[0] == "diff" || args[0] == "validate" || args[0] == "export") {
+		command = args[0]
+		args = args[1:]
 	}
 
-	cfg, err := eqemuconfig.GetConfig()
-	if err != nil {
-		return err
+	flags := flag.NewFlagSet("eqitem", flag.ExitOnError)
+	isUpdate := flags.Bool("update", false, "update existing items that differ from items.txt")
+	format := flags.String("format", "text", "diff or validate report format: text, json or csv")
+	batchSize := flags.Int("batch", 0, "commit every N written rows, 0 runs the whole import in a single transaction")
+	isBulk := flags.Bool("bulk", false, "preload existing ids and insert missing items with multi-row statements")
+	mappingPath := flags.String("mapping", "", "json file overriding how items.txt headers map to item columns")
+	ids := flags.String("ids", "", "only import these comma separated item ids")
+	idRanges := flags.String("id-range", "", "only import item ids in these comma separated min-max ranges")
+	idsFile := flags.String("ids-file", "", "only import item ids listed in this file")
+	whereFilter := flags.String("where", "", "only import items matching this expression, e.g. \"reqlevel<=60 && classes&8\"")
+	idOffset := flags.Int64("id-offset", 0, "add N to every imported item id")
+	idMap := flags.String("id-map", "", "csv file of old_id,new_id pairs to rewrite item ids with")
+	idMapOut := flags.String("id-map-out", "id-map-out.csv", "where to write the old_id,new_id pairs used when remapping ids")
+	isCheckSpells := flags.Bool("check-spells", false, "report spells referenced by items that are missing from spells_new")
+	spellsPath := flags.String("spells", "", "import spells referenced by items that are missing from spells_new from this spells_us.txt")
+	overflow := flags.String("overflow", "warn", "what to do with values too large for their column: warn, clamp or error")
+	decimal := flags.String("decimal", item.DecimalTruncate, "how decimal values are stored in integer columns: round, truncate or reject")
+	inputFormat := flags.String("input-format", "sodeq", "format of the items file read: sodeq (pipe delimited items.txt), csv or jsonl")
+	outputFormat := flags.String("output-format", "sodeq", "format export writes: sodeq (pipe delimited items.txt), csv or jsonl")
+	from := flags.String("from", "", "export items from this file instead of the database")
+	targetFlag := flags.String("target", "", "database to import into: mysql (from eqemu_config) or sqlite:path.db")
+	isFailFast := flags.Bool("fail-fast", false, "stop at the first line of the items file that fails to parse instead of skipping it")
+	sqlOut := flags.String("sql-out", "", "write insert and update statements to this file instead of executing them")
+	workers := flags.Int("workers", 1, "database writers, each writing its share of items in its own batches")
+	checkpointPath := flags.String("checkpoint", "eqitem.checkpoint", "where to record the last committed line, for --resume")
+	isResume := flags.Bool("resume", false, "continue the import from the line recorded in --checkpoint")
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		fmt.Println("usage: eqitem [diff|validate|export] [--update] [--format text|json|csv] [--sql-out file.sql] [--batch N] [--bulk] [--mapping mapping.json] [--ids 1,2] [--id-range min-max] [--ids-file ids.txt] [--where expr] [--id-offset N] [--id-map map.csv] [--check-spells] [--spells spells_us.txt] [--overflow warn|clamp|error] [--decimal round|truncate|reject] [--input-format sodeq|csv|jsonl] [--output-format sodeq|csv|jsonl] [--from items.txt] [--target mysql|sqlite:path.db] [--fail-fast] [--workers N] [--checkpoint eqitem.checkpoint] [--resume] items.txt|items.txt.gz|- [itemid]")
+		os.Exit(1)
 	}
 
-	conn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", cfg.Database.Username, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.Db)
-	db, err := sqlx.Open("mysql", conn)
-	if err != nil {
-		return errors.Wrap(err, "sql open")
+	errorMode := item.SkipOnError
+	if *isFailFast {
+		errorMode = item.FailFast
 	}
-	defer db.Close()
 
-	path := os.Args[1]
-	f, err := os.Open(path)
+	//an interrupt stops reading, so whatever has not been committed is rolled back
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		<-interrupt
+		log.Warn().Msg("interrupted, stopping")
+		cancel()
+	}()
+
+	target, err := parseTarget(*targetFlag)
 	if err != nil {
 		return err
 	}
-	var itemid int64
-	if len(os.Args) > 2 {
-		itemid, err = strconv.ParseInt(os.Args[2], 10, 64)
+	filter := &idFilter{}
+	if flags.NArg() > 1 {
+		itemid, err := strconv.ParseInt(flags.Arg(1), 10, 64)
 		if err != nil {
 			return err
 		}
+		filter.addID(itemid)
+	}
+	if err = filter.addIDs(*ids); err != nil {
+		return errors.Wrap(err, "ids")
+	}
+	if err = filter.addRanges(*idRanges); err != nil {
+		return errors.Wrap(err, "id-range")
+	}
+	if *idsFile != "" {
+		if err = filter.addFile(*idsFile); err != nil {
+			return errors.Wrap(err, "ids-file")
+		}
+	}
+	var where *whereExpr
+	if *whereFilter != "" {
+		where, err = parseWhere(*whereFilter)
+		if err != nil {
+			return errors.Wrap(err, "where")
+		}
+	}
+
+	mapping := &item.Mapping{}
+	if *mappingPath != "" {
+		mapping, err = item.LoadMapping(*mappingPath)
+		if err != nil {
+			return errors.Wrap(err, "mapping")
+		}
 	}
+	if !item.IsDecimalPolicy(*decimal) {
+		return fmt.Errorf("unknown decimal policy: %s", *decimal)
+	}
+	mapping.Decimal = *decimal
 
-	r := csv.NewReader(f)
-	r.Comma = '|'
-	r.LazyQuotes = true
+	if command == "export" && *from != "" {
+		//exporting between files never touches the database
+		f, err := openInput(*from)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		ir, err := item.NewReader(ctx, f, *inputFormat, mapping, errorMode)
+		if err != nil {
+			return err
+		}
+		defer ir.Close()
+		ir.OnSkip = logSkipped
+		ex, out, err := newItemExporter(flags.Arg(0), *outputFormat, filter, where)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		if err = ex.exportReader(ir); err != nil {
+			return err
+		}
+		reportSkipped(ir)
+		reportLosses(ex.losses)
+		log.Info().Msgf("exported %d items", ex.count)
+		return nil
+	}
+
+	if *workers < 1 {
+		return fmt.Errorf("workers must be at least 1")
+	}
+	if *workers > 1 && (*sqlOut != "" || target.dialect == item.DialectSQLite) {
+		return fmt.Errorf("--sql-out and sqlite targets only support a single writer")
+	}
+	if *workers > 1 && *batchSize == 0 && command == "import" {
+		//each writer has its own transaction, so an import over several can not be all or nothing
+		return fmt.Errorf("--workers needs --batch N, a single transaction can not span several writers")
+	}
+	if target.dialect == item.DialectSQLite && (*isCheckSpells || *spellsPath != "" || command == "validate") {
+		return fmt.Errorf("spell and validate checks need the spells_new and faction_list tables, which a sqlite target does not have")
+	}
+	//diffs, exports and sql scripts only read from the database
+	db, err := target.open(mapping.Columns, *sqlOut != "" || command == "diff" || command == "export")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
 
 	total := 0
 	err = db.Get(&total, "SELECT COUNT(id) FROM items")
@@ -99,55 +216,297 @@ func run() error {
 	}
 
 	log.Info().Msgf("eqitem %s", Version)
-	ids := []string{}
 
-	header := []string{}
-	lineCount := 0
-	for {
-		lineCount++
-		record, err := r.Read()
-		if err == io.EOF {
-			break
+	schema, err := item.LoadSchema(db, target.dialect)
+	if err != nil {
+		return errors.Wrap(err, "item schema")
+	}
+	schema.Renames = mapping.Columns
+	tagOnly, optionalOnly, tableOnly := schema.Mismatches()
+	if len(tagOnly) > 0 {
+		log.Warn().Msgf("skipping columns missing from items table: %s", strings.Join(tagOnly, ", "))
+	}
+	if len(optionalOnly) > 0 {
+		log.Info().Msgf("optional columns missing from items table, their values will be dropped: %s", strings.Join(optionalOnly, ", "))
+	}
+	if len(tableOnly) > 0 {
+		log.Warn().Msgf("items table columns not set by eqitem: %s", strings.Join(tableOnly, ", "))
+	}
+
+	stores := []*sqlItemStore{}
+	for i := 0; i < *workers; i++ {
+		stores = append(stores, newSQLItemStore(db, schema, *batchSize))
+	}
+	store := stores[0]
+
+	if command == "export" {
+		ex, out, err := newItemExporter(flags.Arg(0), *outputFormat, filter, where)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		if err = ex.exportStore(store); err != nil {
+			return err
 		}
+		log.Info().Msgf("exported %d items", ex.count)
+		return nil
+	}
+
+	path := flags.Arg(0)
+	if *isResume && (command != "import" || path == "-" || *sqlOut != "") {
+		return fmt.Errorf("--resume only applies to importing an items file into the database")
+	}
+	//checkpoints are only kept for imports that commit to the database, reading a file that can be read again
+	var checkpoints *checkpointWriter
+	resumeLine := 0
+	if command == "import" && path != "-" && *sqlOut == "" {
+		checksum, err := fileChecksum(path)
 		if err != nil {
-			log.Warn().Err(err).Int("line", lineCount).Msg("read")
-			continue
+			return errors.Wrap(err, "checksum")
 		}
-		if lineCount == 1 {
-			header = record
-			continue
+		cp := checkpoint{Path: path, Checksum: checksum}
+		if *isResume {
+			last, err := loadCheckpoint(*checkpointPath)
+			if err != nil {
+				return errors.Wrap(err, "checkpoint")
+			}
+			if last.Checksum != checksum {
+				return fmt.Errorf("%s has changed since checkpoint %s was saved", path, *checkpointPath)
+			}
+			cp = *last
+			resumeLine = cp.Line
+			log.Info().Msgf("resuming after line %d (item %d)", cp.Line, cp.ID)
+		} else if _, err := os.Stat(*checkpointPath); err == nil {
+			log.Warn().Msgf("replacing checkpoint %s, pass --resume to continue the import it is from", *checkpointPath)
+		}
+		checkpoints = newCheckpointWriter(*checkpointPath, cp, len(stores))
+		for i, store := range stores {
+			writer := i
+			store.batch.onCommit = func(lastLine int) {
+				checkpoints.commit(writer, lastLine)
+			}
 		}
+	}
+
+	f, err := openInput(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := item.NewReader(ctx, f, *inputFormat, mapping, errorMode)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	r.OnSkip = logSkipped
+	r.Workers = runtime.NumCPU()
 
-		//temporary
-		item, err := NewItem(header, record)
+	imp := &importer{
+		schema:   schema,
+		isUpdate: *isUpdate,
+		overflow: *overflow,
+		dropped:  droppedColumns{},
+	}
+	imp.checkpoints = checkpoints
+	for _, store := range stores {
+		imp.stores = append(imp.stores, store)
+	}
+	if imp.overflow != "warn" && imp.overflow != "clamp" && imp.overflow != "error" {
+		return fmt.Errorf("unknown overflow policy: %s", imp.overflow)
+	}
+
+	if *sqlOut != "" {
+		out, err := os.Create(*sqlOut)
 		if err != nil {
-			log.Warn().Err(err).Int("line", lineCount).Msg("newItem")
+			return errors.Wrap(err, "sql out")
 		}
+		defer out.Close()
+		store.sw = &sqlWriter{w: out, dialect: target.dialect}
+	}
 
-		if itemid > 0 && item.ID != itemid {
-			continue
+	if *idOffset != 0 || *idMap != "" {
+		idPairs := map[int64]int64{}
+		if *idMap != "" {
+			idPairs, err = loadIDMap(*idMap)
+			if err != nil {
+				return errors.Wrap(err, "id map")
+			}
 		}
+		out, err := os.Create(*idMapOut)
+		if err != nil {
+			return errors.Wrap(err, "id map out")
+		}
+		defer out.Close()
+		imp.remapper, err = newIDRemapper(*idOffset, idPairs, out)
+		if err != nil {
+			return errors.Wrap(err, "id map out")
+		}
+	}
 
-		oldItem := new(EQEmuItem)
-		row := db.QueryRowx("SELECT * FROM items where id = ?", item.ID)
-		if err = row.StructScan(oldItem); err != nil {
-			if err == sql.ErrNoRows {
-				if _, err = db.NamedExec(item.insertQuery(), item); err != nil {
-					return errors.Wrapf(err, "insert %d", item.ID)
-				}
-				log.Info().Msgf("inserted %d", item.ID)
-				ids = append(ids, fmt.Sprintf("%d", item.ID))
+	if *isCheckSpells || *spellsPath != "" {
+		imp.spells, err = loadSpellChecker(db)
+		if err != nil {
+			return errors.Wrap(err, "spells")
+		}
+	}
+
+	var val *validator
+	if command == "validate" {
+		if *format != "text" && *format != "json" && *format != "csv" {
+			return fmt.Errorf("unknown validate format: %s", *format)
+		}
+		val, err = newValidator(db, schema)
+		if err != nil {
+			return errors.Wrap(err, "validator")
+		}
+		r.OnSkip = val.skip
+	}
+
+	if command == "diff" {
+		imp.dw, err = newDiffWriter(os.Stdout, *format)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *isBulk {
+		for _, store := range stores {
+			if imp.dw != nil {
+				err = store.preload()
+			} else {
+				err = store.enableBulk()
+			}
+			if err != nil {
+				return err
+			}
+		}
+		imp.isPreloaded = true
+	}
+
+	if val == nil {
+		imp.start()
+	}
+	//precision lost on items that are filtered out or were committed by an earlier run is not reported
+	losses := []*item.PrecisionLoss{}
+	lineCount := 0
+	for r.Next() {
+		parsed := r.Item()
+		lineCount = r.Line()
+		if !filter.match(parsed.ID) {
+			continue
+		}
+		if where != nil {
+			isMatch, err := where.match(parsed)
+			if err != nil {
+				return imp.abort(errors.Wrapf(err, "where %d", parsed.ID))
+			}
+			if !isMatch {
 				continue
 			}
-			return errors.Wrap(err, "old item")
+		}
+		if val != nil {
+			losses = append(losses, r.Losses()...)
+			val.check(lineCount, parsed)
+			continue
+		}
+		if lineCount <= resumeLine {
+			//committed by an earlier run, remapped again so the id map out stays complete
+			if imp.remapper != nil {
+				if _, err = imp.remapper.remap(parsed); err != nil {
+					return imp.abort(err)
+				}
+			}
+			continue
+		}
+
+		losses = append(losses, r.Losses()...)
+		if err = imp.process(lineCount, parsed); err != nil {
+			return imp.abort(err)
 		}
 		if lineCount%1000 == 0 {
 			log.Info().Msgf("processed %d lines...", lineCount)
 		}
 	}
+	if err = r.Err(); err != nil {
+		return imp.abort(errors.Wrap(err, "read"))
+	}
+	if err = imp.finish(); err != nil {
+		return imp.abort(err)
+	}
 
+	reportSkipped(r)
+	reportLosses(losses)
+	imp.dropped.report()
+
+	if val != nil {
+		if err = val.write(os.Stdout, *format); err != nil {
+			return errors.Wrap(err, "validate write")
+		}
+		if val.errors > 0 {
+			return fmt.Errorf("validation found %d errors and %d warnings", val.errors, val.warnings)
+		}
+		return nil
+	}
+
+	if imp.spells != nil && *spellsPath != "" && imp.dw == nil {
+		sf, err := openInput(*spellsPath)
+		if err != nil {
+			return imp.abort(errors.Wrap(err, "spells"))
+		}
+		spellIDs, err := imp.spells.importSpells(db, sf, func(query string) error {
+			return store.execStatement(lineCount, query)
+		})
+		sf.Close()
+		if err != nil {
+			return imp.abort(errors.Wrap(err, "spells"))
+		}
+		log.Info().Msgf("imported %d spells", len(spellIDs))
+	}
+	if imp.spells != nil {
+		imp.spells.report()
+	}
+	if err = imp.commit(); err != nil {
+		return err
+	}
+	if checkpoints != nil {
+		if err = checkpoints.remove(); err != nil {
+			log.Warn().Err(err).Msg("remove checkpoint")
+		}
+	}
 	log.Debug().Msgf("processed %d lines", lineCount)
 
-	log.Info().Msgf("id dump: %s", strings.Join(ids, ", "))
+	if imp.remapper != nil {
+		if err = imp.remapper.Close(); err != nil {
+			return errors.Wrap(err, "id map out")
+		}
+	}
+
+	if imp.dw != nil {
+		return imp.dw.Close()
+	}
+
+	if store.sw == nil {
+		rows, batches := 0, 0
+		for _, store := range stores {
+			rows += store.batch.total
+			batches += store.batch.number
+		}
+		log.Info().Msgf("committed %d rows in %d batches", rows, batches)
+	}
+	log.Info().Msgf("id dump: %s", strings.Join(imp.ids, ", "))
 	return nil
 }
+
+// logSkipped warns about a line of the items file that failed to parse
+func logSkipped(line int, err error) {
+	log.Warn().Err(err).Int("line", line).Msg("read")
+}
+
+// reportSkipped warns how many lines of the items file failed to parse, once reading is done
+func reportSkipped(r *item.Reader) {
+	if r.Skipped() == 0 {
+		return
+	}
+	log.Warn().Msgf("skipped %d lines that failed to parse", r.Skipped())
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/eqitem/item"
+)
+
+// memoryItemStore keeps items in memory, for tests and tooling that do not need a database.
+// Writes are pending until Commit, and Rollback discards them
+type memoryItemStore struct {
+	items   map[int64]*item.EQEmuItem
+	pending map[int64]*item.EQEmuItem
+}
+
+func newMemoryItemStore() *memoryItemStore {
+	return &memoryItemStore{
+		items:   map[int64]*item.EQEmuItem{},
+		pending: map[int64]*item.EQEmuItem{},
+	}
+}
+
+func (st *memoryItemStore) Get(id int64) (*item.EQEmuItem, error) {
+	item, ok := st.pending[id]
+	if !ok {
+		item, ok = st.items[id]
+	}
+	if !ok {
+		return nil, nil
+	}
+	//copy so callers can not change the stored item
+	stored := *item
+	return &stored, nil
+}
+
+func (st *memoryItemStore) Exists(id int64) (bool, error) {
+	item, err := st.Get(id)
+	return item != nil, err
+}
+
+func (st *memoryItemStore) Insert(line int, item *item.EQEmuItem) error {
+	if ok, _ := st.Exists(item.ID); ok {
+		return fmt.Errorf("line %d: item %d already exists", line, item.ID)
+	}
+	stored := *item
+	st.pending[item.ID] = &stored
+	return nil
+}
+
+func (st *memoryItemStore) Update(line int, item *item.EQEmuItem) error {
+	if ok, _ := st.Exists(item.ID); !ok {
+		return fmt.Errorf("line %d: item %d does not exist", line, item.ID)
+	}
+	stored := *item
+	st.pending[item.ID] = &stored
+	return nil
+}
+
+func (st *memoryItemStore) Iterate(fn func(item *item.EQEmuItem) error) error {
+	ids := []int64{}
+	for id := range st.items {
+		if _, ok := st.pending[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	for id := range st.pending {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		item, err := st.Get(id)
+		if err != nil {
+			return err
+		}
+		if err = fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *memoryItemStore) Commit() error {
+	for id, item := range st.pending {
+		st.items[id] = item
+	}
+	st.pending = map[int64]*item.EQEmuItem{}
+	return nil
+}
+
+func (st *memoryItemStore) Rollback(cause error) error {
+	count := len(st.pending)
+	st.pending = map[int64]*item.EQEmuItem{}
+	if count == 0 {
+		return cause
+	}
+	return errors.Wrapf(cause, "rolled back %d items", count)
+}
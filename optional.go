@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xackery/eqitem/item"
+)
+
+// droppedColumns counts, per optional column, the items with a value that the items table has no column for
+type droppedColumns map[string]int
+
+// add counts every optional column item has a value for that schema is missing
+func (dropped droppedColumns) add(schema *item.Schema, item *item.EQEmuItem) {
+	for _, tag := range schema.Dropped(item) {
+		dropped[tag]++
+	}
+}
+
+// report logs how many values were dropped for each optional column
+func (dropped droppedColumns) report() {
+	if len(dropped) == 0 {
+		return
+	}
+	columns := []string{}
+	for column, count := range dropped {
+		columns = append(columns, fmt.Sprintf("%s (%d items)", column, count))
+	}
+	sort.Strings(columns)
+	log.Warn().Msgf("dropped values for optional columns missing from items table: %s", strings.Join(columns, ", "))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/eqitem/item"
+)
+
+// idRemapper rewrites item ids before import, so custom content servers can avoid colliding with live ids.
+// Ids listed in the map are replaced, every other id has offset added
+type idRemapper struct {
+	offset int64
+	ids    map[int64]int64
+	used   map[int64]int64
+	out    *csv.Writer
+}
+
+func newIDRemapper(offset int64, ids map[int64]int64, out io.Writer) (*idRemapper, error) {
+	rm := &idRemapper{
+		offset: offset,
+		ids:    ids,
+		used:   map[int64]int64{},
+		out:    csv.NewWriter(out),
+	}
+	if err := rm.out.Write([]string{"old_id", "new_id"}); err != nil {
+		return nil, err
+	}
+	return rm, nil
+}
+
+// loadIDMap reads old,new id pairs from a csv file. A header line is allowed
+func loadIDMap(path string) (map[int64]int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+
+	ids := map[int64]int64{}
+	lineCount := 0
+	for {
+		lineCount++
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineCount)
+		}
+		oldID, err := strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
+		if err != nil {
+			if lineCount == 1 {
+				continue
+			}
+			return nil, errors.Wrapf(err, "line %d old id", lineCount)
+		}
+		newID, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d new id", lineCount)
+		}
+		if _, ok := ids[oldID]; ok {
+			return nil, fmt.Errorf("line %d: id %d is mapped more than once", lineCount, oldID)
+		}
+		ids[oldID] = newID
+	}
+	return ids, nil
+}
+
+// remap sets item's new id, returning the original.
+// An error is returned if two items would be given the same id
+func (rm *idRemapper) remap(item *item.EQEmuItem) (int64, error) {
+	oldID := item.ID
+	newID, ok := rm.ids[oldID]
+	if !ok {
+		newID = oldID + rm.offset
+	}
+	if prevID, ok := rm.used[newID]; ok && prevID != oldID {
+		return oldID, fmt.Errorf("id %d and %d both remap to %d", prevID, oldID, newID)
+	}
+	rm.used[newID] = oldID
+	item.ID = newID
+	if err := rm.out.Write([]string{strconv.FormatInt(oldID, 10), strconv.FormatInt(newID, 10)}); err != nil {
+		return oldID, errors.Wrap(err, "write id map")
+	}
+	return oldID, nil
+}
+
+// Close flushes the written id map
+func (rm *idRemapper) Close() error {
+	rm.out.Flush()
+	return rm.out.Error()
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/xackery/eqitem/item"
+)
+
+// spellRef is an item field that references a spells_new id
+type spellRef struct {
+	ItemID int64
+	Field  string
+}
+
+// itemSpells returns every spell id referenced by item, keyed by field
+func itemSpells(item *item.EQEmuItem) map[string]int64 {
+	return map[string]int64{
+		"clickeffect":  item.Clickeffect,
+		"proceffect":   item.Proceffect,
+		"worneffect":   item.Worneffect,
+		"focuseffect":  item.Focuseffect,
+		"scrolleffect": item.Scrolleffect,
+		"bardeffect":   item.Bardeffect,
+	}
+}
+
+// spellChecker tracks item spell references that are missing from spells_new
+type spellChecker struct {
+	existing map[int64]bool
+	missing  map[int64][]spellRef
+}
+
+// loadSpellChecker preloads every spell id in spells_new
+func loadSpellChecker(db *sqlx.DB) (*spellChecker, error) {
+	ids := []int64{}
+	if err := db.Select(&ids, "SELECT id FROM spells_new"); err != nil {
+		return nil, errors.Wrap(err, "select spell ids")
+	}
+	sc := &spellChecker{
+		existing: make(map[int64]bool, len(ids)),
+		missing:  map[int64][]spellRef{},
+	}
+	for _, id := range ids {
+		sc.existing[id] = true
+	}
+	return sc, nil
+}
+
+// check records any spells item references that spells_new does not have
+func (sc *spellChecker) check(item *item.EQEmuItem) {
+	for field, spellID := range itemSpells(item) {
+		if spellID <= 0 || sc.existing[spellID] {
+			continue
+		}
+		sc.missing[spellID] = append(sc.missing[spellID], spellRef{ItemID: item.ID, Field: field})
+	}
+}
+
+// missingIDs returns the missing spell ids in ascending order
+func (sc *spellChecker) missingIDs() []int64 {
+	ids := []int64{}
+	for id := range sc.missing {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// report logs every missing spell and the items that reference it
+func (sc *spellChecker) report() {
+	for _, id := range sc.missingIDs() {
+		refs := []string{}
+		for _, ref := range sc.missing[id] {
+			refs = append(refs, fmt.Sprintf("%d (%s)", ref.ItemID, ref.Field))
+		}
+		log.Warn().Msgf("spell %d is missing from spells_new, referenced by %s", id, strings.Join(refs, ", "))
+	}
+}
+
+// importSpells reads a spells_us.txt file and writes a spells_new row for every missing spell found in it.
+// Fields are matched to spells_new columns by position, the same way eqemu's import_spells does
+func (sc *spellChecker) importSpells(db *sqlx.DB, r io.Reader, exec func(query string) error) ([]int64, error) {
+	columns := []string{}
+	err := db.Select(&columns, "SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'spells_new' ORDER BY ORDINAL_POSITION")
+	if err != nil {
+		return nil, errors.Wrap(err, "select columns")
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("spells_new table not found")
+	}
+
+	imported := []int64{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineCount := 0
+	for scanner.Scan() {
+		lineCount++
+		record := strings.Split(strings.TrimRight(scanner.Text(), "\r"), "^")
+		id, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			log.Warn().Err(err).Int("line", lineCount).Msg("spell id")
+			continue
+		}
+		if _, ok := sc.missing[id]; !ok {
+			continue
+		}
+
+		count := len(record)
+		if count > len(columns) {
+			count = len(columns)
+		}
+		fields := []string{}
+		values := []string{}
+		for i := 0; i < count; i++ {
+			fields = append(fields, fmt.Sprintf("`%s`", columns[i]))
+			values = append(values, spellLiteral(record[i]))
+		}
+		query := fmt.Sprintf("INSERT INTO spells_new (%s) VALUES (%s);", strings.Join(fields, ", "), strings.Join(values, ", "))
+		if err = exec(query); err != nil {
+			return imported, errors.Wrapf(err, "insert spell %d", id)
+		}
+		delete(sc.missing, id)
+		sc.existing[id] = true
+		imported = append(imported, id)
+	}
+	if err = scanner.Err(); err != nil {
+		return imported, errors.Wrapf(err, "line %d", lineCount)
+	}
+	return imported, nil
+}
+
+// spellNumber matches the plain decimal numbers spellLiteral leaves unquoted. strconv would also accept inf and nan,
+// which mysql reads as identifiers
+var spellNumber = regexp.MustCompile(`^-?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+
+// spellLiteral renders a spells_us.txt field, leaving numbers unquoted and empty fields as the column default
+func spellLiteral(value string) string {
+	if value == "" {
+		return "DEFAULT"
+	}
+	if spellNumber.MatchString(value) {
+		return value
+	}
+	return item.DialectMySQL.Quote(value)
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestSpellLiteral(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "", want: "DEFAULT"},
+		{value: "12", want: "12"},
+		{value: "-3", want: "-3"},
+		{value: "0.5", want: "0.5"},
+		{value: ".5", want: ".5"},
+		{value: "2.", want: "2."},
+		{value: "inf", want: "'inf'"},
+		{value: "Infinity", want: "'Infinity'"},
+		{value: "NaN", want: "'NaN'"},
+		{value: "1e5", want: "'1e5'"},
+		{value: "0x10", want: "'0x10'"},
+		{value: "Fire Bolt", want: "'Fire Bolt'"},
+		{value: "it's", want: "'it\\'s'"},
+	}
+	for _, tt := range tests {
+		if got := spellLiteral(tt.value); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
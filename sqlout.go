@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xackery/eqitem/item"
+)
+
+// sqlWriter renders named queries as plain sql statements instead of executing them
+type sqlWriter struct {
+	w       io.Writer
+	dialect item.Dialect
+}
+
+// Exec writes query with arg's values bound in place of each named parameter
+func (sw *sqlWriter) Exec(query string, arg interface{}) error {
+	rendered, err := item.RenderQuery(query, arg, sw.dialect)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(sw.w, rendered)
+	return err
+}
+
+// Write writes an already rendered statement
+func (sw *sqlWriter) Write(query string) error {
+	_, err := fmt.Fprintln(sw.w, query)
+	return err
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xackery/eqitem/item"
+)
+
+// ItemStore is where imported items are read from and written to
+type ItemStore interface {
+	// Get returns the stored item with id, or nil if there is none
+	Get(id int64) (*item.EQEmuItem, error)
+	Exists(id int64) (bool, error)
+	// Insert writes a new item found on line of the input
+	Insert(line int, item *item.EQEmuItem) error
+	// Update overwrites the stored item with the same id
+	Update(line int, item *item.EQEmuItem) error
+	// Iterate calls fn with every stored item, ordered by id
+	Iterate(fn func(item *item.EQEmuItem) error) error
+	// Commit makes every pending write permanent
+	Commit() error
+	// Rollback discards pending writes and describes what was lost alongside cause
+	Rollback(cause error) error
+}
+
+// sqlItemStore stores items in the items table of a mysql or sqlite database, depending on the schema's dialect
+type sqlItemStore struct {
+	db       *sqlx.DB
+	schema   *item.Schema
+	batch    *importBatch
+	sw       *sqlWriter
+	bulk     *bulkInserter
+	existing map[int64]bool
+}
+
+func newSQLItemStore(db *sqlx.DB, schema *item.Schema, batchSize int) *sqlItemStore {
+	return &sqlItemStore{
+		db:     db,
+		schema: schema,
+		batch:  newImportBatch(db, batchSize),
+	}
+}
+
+// preload reads every item id in the items table so Exists does not need a query per item
+func (st *sqlItemStore) preload() error {
+	ids := []int64{}
+	if err := st.db.Select(&ids, "SELECT id FROM items"); err != nil {
+		return errors.Wrap(err, "select ids")
+	}
+	st.existing = make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		st.existing[id] = true
+	}
+	return nil
+}
+
+// enableBulk switches inserts to multi-row statements sized to the server's max_allowed_packet
+func (st *sqlItemStore) enableBulk() error {
+	maxPacket := bulkPacketLimit
+	if st.schema.Dialect() == item.DialectMySQL {
+		if err := st.db.Get(&maxPacket, "SELECT @@max_allowed_packet"); err != nil {
+			return errors.Wrap(err, "max_allowed_packet")
+		}
+	}
+	if err := st.preload(); err != nil {
+		return err
+	}
+	st.bulk = newBulkInserter(st.schema, maxPacket, st.execRaw)
+	return nil
+}
+
+func (st *sqlItemStore) Get(id int64) (*item.EQEmuItem, error) {
+	item := new(item.EQEmuItem)
+	err := st.batch.QueryRowx(item.SelectQuery(st.schema), id).StructScan(item)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (st *sqlItemStore) Exists(id int64) (bool, error) {
+	if st.existing != nil {
+		return st.existing[id], nil
+	}
+	count := 0
+	if err := st.batch.QueryRowx("SELECT COUNT(id) FROM items WHERE `id` = ?", id).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (st *sqlItemStore) Insert(line int, item *item.EQEmuItem) error {
+	if st.existing != nil {
+		st.existing[item.ID] = true
+	}
+	if st.bulk == nil {
+		return st.exec(line, item.InsertQuery(st.schema), item)
+	}
+	if err := st.bulk.Add(line, item); err != nil {
+		return err
+	}
+	//buffered rows count against the batch size, flushing them is what commits the batch
+	if st.batch.size > 0 && st.batch.rows+st.bulk.rows >= st.batch.size {
+		return st.bulk.Flush()
+	}
+	return nil
+}
+
+func (st *sqlItemStore) Update(line int, item *item.EQEmuItem) error {
+	//buffered inserts are written first, so a batch never commits past a line that is still buffered
+	if st.bulk != nil {
+		if err := st.bulk.Flush(); err != nil {
+			return err
+		}
+	}
+	return st.exec(line, item.UpdateQuery(st.schema), item)
+}
+
+func (st *sqlItemStore) Iterate(fn func(item *item.EQEmuItem) error) error {
+	rows, err := st.db.Queryx(new(item.EQEmuItem).SelectAllQuery(st.schema))
+	if err != nil {
+		return errors.Wrap(err, "select items")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := new(item.EQEmuItem)
+		if err = rows.StructScan(item); err != nil {
+			return errors.Wrap(err, "scan item")
+		}
+		if err = fn(item); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrap(err, "rows")
+	}
+	return nil
+}
+
+// Commit writes any pending bulk inserts and commits the open batch
+func (st *sqlItemStore) Commit() error {
+	if st.bulk != nil {
+		if err := st.bulk.Flush(); err != nil {
+			return st.batch.Rollback(err)
+		}
+	}
+	return st.batch.Commit()
+}
+
+// Rollback discards buffered bulk inserts and the open batch, describing both alongside cause
+func (st *sqlItemStore) Rollback(cause error) error {
+	if st.bulk != nil {
+		firstLine, lastLine := st.bulk.firstLine, st.bulk.lastLine
+		if rows := st.bulk.Discard(); rows > 0 {
+			cause = errors.Wrapf(cause, "%d buffered rows (lines %d-%d) discarded", rows, firstLine, lastLine)
+		}
+	}
+	return st.batch.Rollback(cause)
+}
+
+// execRaw writes an already rendered statement covering rows items on lines firstLine to lastLine
+func (st *sqlItemStore) execRaw(firstLine int, lastLine int, query string, rows int) error {
+	if st.sw != nil {
+		return st.sw.Write(query)
+	}
+	return st.batch.ExecBulk(firstLine, lastLine, query, rows)
+}
+
+// execStatement writes a rendered statement for line that is not an item insert, such as an imported spell.
+// Buffered inserts are written first, so a batch it commits never covers a line that is still buffered
+func (st *sqlItemStore) execStatement(line int, query string) error {
+	if st.bulk != nil {
+		if err := st.bulk.Flush(); err != nil {
+			return err
+		}
+	}
+	return st.execRaw(line, line, query, 1)
+}
+
+// exec writes query to the sql script when one is set, otherwise runs it in the current batch
+func (st *sqlItemStore) exec(line int, query string, item *item.EQEmuItem) error {
+	if st.sw != nil {
+		return st.sw.Exec(query, item)
+	}
+	return st.batch.Exec(line, query, item)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/xackery/eqitem/item"
+)
+
+// TestScanTextDatetime checks that datetimes stored as text, the way mysql returns them without parseTime, scan into items
+func TestScanTextDatetime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eqitem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, _ := openTestSQLite(t, dir)
+	defer db.Close()
+
+	if _, err = db.Exec("CREATE TABLE text_items (id INTEGER, serialization TEXT, serialized TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = db.Exec("INSERT INTO text_items VALUES (1, '2020-01-02 03:04:05', NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	it := &item.EQEmuItem{}
+	if err = db.Get(it, "SELECT id, serialization, serialized FROM text_items WHERE id = 1"); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if !it.Serialization.Valid || !it.Serialization.Time.Equal(want) {
+		t.Errorf("serialization: got %v, want %v", it.Serialization, want)
+	}
+	if it.Serialized.Valid {
+		t.Errorf("serialized: got %v, want NULL", it.Serialized)
+	}
+}
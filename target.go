@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xackery/eqemuconfig"
+	"github.com/xackery/eqitem/item"
+)
+
+// itemTarget is the database items are imported into, e.g. mysql or sqlite:items.db
+type itemTarget struct {
+	dialect item.Dialect
+	path    string
+}
+
+// parseTarget parses a --target value. An empty value or mysql uses the database in eqemu_config
+func parseTarget(value string) (*itemTarget, error) {
+	if value == "" || value == "mysql" {
+		return &itemTarget{dialect: item.DialectMySQL}, nil
+	}
+	if strings.HasPrefix(value, "sqlite:") {
+		path := strings.TrimPrefix(value, "sqlite:")
+		if path == "" {
+			return nil, fmt.Errorf("sqlite target needs a path, e.g. sqlite:items.db")
+		}
+		return &itemTarget{dialect: item.DialectSQLite, path: path}, nil
+	}
+	return nil, fmt.Errorf("unknown target: %s", value)
+}
+
+// open connects to the target. A sqlite target is created, along with its items table, if it does not exist,
+// unless isReadOnly is set, in which case it is opened read only and must already exist
+func (t *itemTarget) open(renames map[string]string, isReadOnly bool) (*sqlx.DB, error) {
+	if t.dialect == item.DialectSQLite {
+		if isReadOnly {
+			if _, err := os.Stat(t.path); err != nil {
+				return nil, err
+			}
+			db, err := sqlx.Open("sqlite3", "file:"+t.path+"?mode=ro")
+			if err != nil {
+				return nil, errors.Wrap(err, "sql open")
+			}
+			return db, nil
+		}
+		db, err := sqlx.Open("sqlite3", t.path)
+		if err != nil {
+			return nil, errors.Wrap(err, "sql open")
+		}
+		_, err = db.Exec(new(item.EQEmuItem).CreateSQLiteQuery(renames))
+		if err != nil {
+			db.Close()
+			return nil, errors.Wrap(err, "create items")
+		}
+		return db, nil
+	}
+
+	cfg, err := eqemuconfig.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	conn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", cfg.Database.Username, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.Db)
+	db, err := sqlx.Open("mysql", conn)
+	if err != nil {
+		return nil, errors.Wrap(err, "sql open")
+	}
+	return db, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteTargetReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eqitem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "items.db")
+	target, err := parseTarget("sqlite:" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//a read only target is never created
+	if _, err = target.open(nil, true); err == nil {
+		t.Fatal("opened a sqlite target that does not exist")
+	}
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("read only open created %s", path)
+	}
+
+	db, _ := openTestSQLite(t, dir, 5)
+	db.Close()
+	before, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err = target.open(nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	count := 0
+	if err = db.Get(&count, "SELECT COUNT(id) FROM items"); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %d items, want 1", count)
+	}
+	if _, err = db.Exec("INSERT INTO items (id) VALUES (6)"); err == nil {
+		t.Errorf("wrote to a read only target")
+	}
+	after, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("read only open changed %s", path)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/xackery/eqitem/item"
+)
+
+// maxAugSlotType is the highest augment type an item slot may accept
+const maxAugSlotType = 30
+
+// validationIssue is a single problem found with an item
+type validationIssue struct {
+	Line    int    `json:"line"`
+	ItemID  int64  `json:"id"`
+	Level   string `json:"level"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// validator checks items against the referenced tables of the target database
+type validator struct {
+	schema   *item.Schema
+	spells   *spellChecker
+	factions map[int64]bool
+	issues   []*validationIssue
+	errors   int
+	warnings int
+}
+
+// newValidator preloads the spell and faction ids items may reference
+func newValidator(db *sqlx.DB, schema *item.Schema) (*validator, error) {
+	spells, err := loadSpellChecker(db)
+	if err != nil {
+		return nil, err
+	}
+	ids := []int64{}
+	if err = db.Select(&ids, "SELECT id FROM faction_list"); err != nil {
+		return nil, errors.Wrap(err, "select faction ids")
+	}
+	v := &validator{
+		schema:   schema,
+		spells:   spells,
+		factions: make(map[int64]bool, len(ids)),
+		issues:   []*validationIssue{},
+	}
+	for _, id := range ids {
+		v.factions[id] = true
+	}
+	return v, nil
+}
+
+func (v *validator) add(line int, item *item.EQEmuItem, level string, field string, format string, args ...interface{}) {
+	if level == "error" {
+		v.errors++
+	} else {
+		v.warnings++
+	}
+	v.issues = append(v.issues, &validationIssue{
+		Line:    line,
+		ItemID:  item.ID,
+		Level:   level,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// skip records a line of the items file that failed to parse as an error, since none of its item was checked
+func (v *validator) skip(line int, err error) {
+	v.errors++
+	v.issues = append(v.issues, &validationIssue{
+		Line:    line,
+		Level:   "error",
+		Field:   "line",
+		Message: err.Error(),
+	})
+}
+
+// check records every issue found with item
+func (v *validator) check(line int, item *item.EQEmuItem) {
+	spells := itemSpells(item)
+	for _, field := range []string{"clickeffect", "proceffect", "worneffect", "focuseffect", "scrolleffect", "bardeffect"} {
+		spellID := spells[field]
+		if spellID > 0 && !v.spells.existing[spellID] {
+			v.add(line, item, "error", field, "spell %d does not exist in spells_new", spellID)
+		}
+	}
+
+	factions := []int64{item.Factionmod1, item.Factionmod2, item.Factionmod3, item.Factionmod4}
+	amounts := []int64{item.Factionamt1, item.Factionamt2, item.Factionamt3, item.Factionamt4}
+	for i, factionID := range factions {
+		field := fmt.Sprintf("factionmod%d", i+1)
+		if factionID == 0 {
+			if amounts[i] != 0 {
+				v.add(line, item, "warning", fmt.Sprintf("factionamt%d", i+1), "amount %d is set without a faction", amounts[i])
+			}
+			continue
+		}
+		if !v.factions[factionID] {
+			v.add(line, item, "error", field, "faction %d does not exist in faction_list", factionID)
+		}
+	}
+
+	isLore := strings.HasPrefix(item.Lore, "*")
+	if isLore && item.Loregroup == 0 {
+		v.add(line, item, "warning", "loregroup", "lore %q is marked lore but loregroup is 0", item.Lore)
+	}
+	if !isLore && item.Loregroup != 0 {
+		v.add(line, item, "warning", "loregroup", "loregroup is %d but lore %q is not marked lore", item.Loregroup, item.Lore)
+	}
+
+	for _, overflow := range v.schema.Overflows(item, false) {
+		v.add(line, item, "error", overflow.Column, "%s", overflow.Message)
+	}
+
+	augTypes := []int64{item.Augslot1type, item.Augslot2type, item.Augslot3type, item.Augslot4type, item.Augslot5type, item.Augslot6type}
+	for i, augType := range augTypes {
+		if augType < 0 || augType > maxAugSlotType {
+			v.add(line, item, "error", fmt.Sprintf("augslot%dtype", i+1), "aug type %d is not between 0 and %d", augType, maxAugSlotType)
+		}
+	}
+}
+
+// write outputs every issue as text, json or csv
+func (v *validator) write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		return enc.Encode(v.issues)
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"line", "id", "level", "field", "message"}); err != nil {
+			return err
+		}
+		for _, issue := range v.issues {
+			err := cw.Write([]string{strconv.Itoa(issue.Line), strconv.FormatInt(issue.ItemID, 10), issue.Level, issue.Field, issue.Message})
+			if err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case "text":
+		for _, issue := range v.issues {
+			if _, err := fmt.Fprintf(w, "%d: %s %s: %s (line %d)\n", issue.ItemID, issue.Level, issue.Field, issue.Message, issue.Line); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "%d errors, %d warnings\n", v.errors, v.warnings)
+		return err
+	}
+	return fmt.Errorf("unknown validate format: %s", format)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xackery/eqitem/item"
+)
+
+func TestValidatorCountsSkippedLines(t *testing.T) {
+	input := "id|name\n1|Cloth Cap\n2\n3|Bad|Cap\n"
+	r, err := item.NewReader(context.Background(), strings.NewReader(input), "sodeq", nil, item.SkipOnError)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	val := &validator{schema: &item.Schema{}, spells: &spellChecker{existing: map[int64]bool{}}, factions: map[int64]bool{}}
+	r.OnSkip = val.skip
+	for r.Next() {
+		val.check(r.Line(), r.Item())
+	}
+	if err = r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if val.errors != 2 {
+		t.Errorf("got %d errors, want the 2 malformed lines", val.errors)
+	}
+	out := &bytes.Buffer{}
+	if err = val.write(out, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	want := "line,id,level,field,message\n3,0,error,line,record on line 3: wrong number of fields\n4,0,error,line,record on line 4: wrong number of fields\n"
+	if out.String() != want {
+		t.Errorf("got report %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,546 @@
+package main
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xackery/eqitem/item"
+)
+
+// whereExpr is a compiled --where filter, e.g. reqlevel<=60 && itemtype==10 && classes&8.
+// Fields are referenced by db or sodaeq name, and operators follow go precedence
+type whereExpr struct {
+	root exprNode
+}
+
+// exprNode evaluates part of an expression against an item's struct value.
+// Results are always int64, float64 or string
+type exprNode func(s reflect.Value) (interface{}, error)
+
+// parseWhere compiles expr
+func parseWhere(expr string) (*whereExpr, error) {
+	tokens, err := lexWhere(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &whereParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s at position %d", p.tokens[p.pos].text, p.tokens[p.pos].pos)
+	}
+	return &whereExpr{root: root.node}, nil
+}
+
+// match returns true if item satisfies the expression
+func (w *whereExpr) match(item *item.EQEmuItem) (bool, error) {
+	val, err := w.root(reflect.ValueOf(item).Elem())
+	if err != nil {
+		return false, err
+	}
+	return exprTruth(val), nil
+}
+
+type exprTokenKind int
+
+const (
+	tokenNumber exprTokenKind = iota
+	tokenString
+	tokenIdent
+	tokenOp
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+	pos  int
+}
+
+// whereOps lists operators, longest first so they are matched greedily
+var whereOps = []string{"&&", "||", "==", "!=", "<=", ">=", "<<", ">>", "<", ">", "!", "&", "|", "^", "+", "-", "*", "/", "%", "(", ")"}
+
+func lexWhere(expr string) ([]exprToken, error) {
+	tokens := []exprToken{}
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			start := i
+			for i < len(expr) && (expr[i] >= '0' && expr[i] <= '9' || expr[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: expr[start:i], pos: start})
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			start := i
+			for i < len(expr) && (expr[i] == '_' || expr[i] >= 'a' && expr[i] <= 'z' || expr[i] >= 'A' && expr[i] <= 'Z' || expr[i] >= '0' && expr[i] <= '9') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: expr[start:i], pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			for i < len(expr) && expr[i] != c {
+				i++
+			}
+			if i >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			tokens = append(tokens, exprToken{kind: tokenString, text: expr[start+1 : i], pos: start})
+			i++
+		default:
+			found := false
+			for _, op := range whereOps {
+				if strings.HasPrefix(expr[i:], op) {
+					tokens = append(tokens, exprToken{kind: tokenOp, text: op, pos: i})
+					i += len(op)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unexpected %q at position %d", c, i)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+type whereParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+// exprKind is the type of value a node evaluates to, known when the expression is parsed
+type exprKind int
+
+const (
+	kindInt exprKind = iota
+	kindFloat
+	kindString
+)
+
+func (k exprKind) String() string {
+	switch k {
+	case kindInt:
+		return "integer"
+	case kindFloat:
+		return "decimal"
+	}
+	return "string"
+}
+
+// exprTerm is a parsed node and the kind of value it evaluates to
+type exprTerm struct {
+	node exprNode
+	kind exprKind
+}
+
+// accept consumes the next token if it is one of ops, returning its position
+func (p *whereParser) accept(ops ...string) (string, int, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", 0, false
+	}
+	token := p.tokens[p.pos]
+	if token.kind != tokenOp {
+		return "", 0, false
+	}
+	for _, op := range ops {
+		if token.text == op {
+			p.pos++
+			return op, token.pos, true
+		}
+	}
+	return "", 0, false
+}
+
+func (p *whereParser) parseOr() (*exprTerm, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, _, ok := p.accept("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprTerm{node: logicalNode(left.node, right.node, true), kind: kindInt}
+	}
+}
+
+func (p *whereParser) parseAnd() (*exprTerm, error) {
+	left, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, _, ok := p.accept("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		left = &exprTerm{node: logicalNode(left.node, right.node, false), kind: kindInt}
+	}
+}
+
+func (p *whereParser) parseCompare() (*exprTerm, error) {
+	return p.parseBinary(p.parseAdd, "==", "!=", "<=", ">=", "<", ">")
+}
+
+func (p *whereParser) parseAdd() (*exprTerm, error) {
+	return p.parseBinary(p.parseMultiply, "+", "-", "|", "^")
+}
+
+func (p *whereParser) parseMultiply() (*exprTerm, error) {
+	return p.parseBinary(p.parseUnary, "*", "/", "%", "&", "<<", ">>")
+}
+
+// parseBinary parses operands with next, joined left to right by any of ops
+func (p *whereParser) parseBinary(next func() (*exprTerm, error), ops ...string) (*exprTerm, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, pos, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		kind, err := binaryKind(op, left.kind, right.kind)
+		if err != nil {
+			return nil, errors.Wrapf(err, "position %d", pos)
+		}
+		left = &exprTerm{node: binaryNode(op, left.node, right.node), kind: kind}
+	}
+}
+
+func (p *whereParser) parseUnary() (*exprTerm, error) {
+	op, pos, ok := p.accept("!", "-")
+	if !ok {
+		return p.parsePrimary()
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	kind := kindInt
+	if op == "-" {
+		if operand.kind == kindString {
+			return nil, fmt.Errorf("position %d: cannot negate a string", pos)
+		}
+		kind = operand.kind
+	}
+	return &exprTerm{kind: kind, node: func(s reflect.Value) (interface{}, error) {
+		val, err := operand.node(s)
+		if err != nil {
+			return nil, err
+		}
+		if op == "!" {
+			return exprBool(!exprTruth(val)), nil
+		}
+		switch v := val.(type) {
+		case int64:
+			return -v, nil
+		case float64:
+			return -v, nil
+		}
+		return nil, fmt.Errorf("cannot negate %v", val)
+	}}, nil
+}
+
+func (p *whereParser) parsePrimary() (*exprTerm, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	if _, _, ok := p.accept("("); ok {
+		term, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, _, ok = p.accept(")"); !ok {
+			return nil, fmt.Errorf("missing )")
+		}
+		return term, nil
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+	switch token.kind {
+	case tokenNumber:
+		var val interface{}
+		kind := kindInt
+		if strings.Contains(token.text, ".") {
+			num, err := strconv.ParseFloat(token.text, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "number at position %d", token.pos)
+			}
+			val = num
+			kind = kindFloat
+		} else {
+			num, err := strconv.ParseInt(token.text, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "number at position %d", token.pos)
+			}
+			val = num
+		}
+		return &exprTerm{kind: kind, node: func(s reflect.Value) (interface{}, error) { return val, nil }}, nil
+	case tokenString:
+		val := token.text
+		return &exprTerm{kind: kindString, node: func(s reflect.Value) (interface{}, error) { return val, nil }}, nil
+	case tokenIdent:
+		index, ok := whereFieldIndex(token.text)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s at position %d", token.text, token.pos)
+		}
+		return &exprTerm{kind: fieldKind(index), node: func(s reflect.Value) (interface{}, error) {
+			return exprFieldValue(s.Field(index)), nil
+		}}, nil
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", token.text, token.pos)
+}
+
+// binaryKind returns the kind op produces from operands of kinds a and b, or an error if op does not apply to them
+func binaryKind(op string, a exprKind, b exprKind) (exprKind, error) {
+	isCompare := op == "==" || op == "!=" || op == "<" || op == "<=" || op == ">" || op == ">="
+	if a == kindString || b == kindString {
+		if a != b {
+			return 0, fmt.Errorf("cannot use %s on %s and %s", op, a, b)
+		}
+		if isCompare {
+			return kindInt, nil
+		}
+		if op == "+" {
+			return kindString, nil
+		}
+		return 0, fmt.Errorf("operator %s is not supported on strings", op)
+	}
+	if isCompare {
+		return kindInt, nil
+	}
+	switch op {
+	case "&", "|", "^", "<<", ">>", "%":
+		if a != kindInt || b != kindInt {
+			return 0, fmt.Errorf("operator %s requires integers", op)
+		}
+	}
+	if a == kindInt && b == kindInt {
+		return kindInt, nil
+	}
+	return kindFloat, nil
+}
+
+// whereFieldIndex finds an EQEmuItem field by db or sodaeq name, ignoring case
+func whereFieldIndex(name string) (int, bool) {
+	st := reflect.TypeOf(item.EQEmuItem{})
+	for _, key := range []string{"db", "sodaeq"} {
+		for i := 0; i < st.NumField(); i++ {
+			tag, ok := st.Field(i).Tag.Lookup(key)
+			if ok && strings.EqualFold(tag, name) {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// fieldKind returns the kind exprFieldValue converts the EQEmuItem field at index to
+func fieldKind(index int) exprKind {
+	switch reflect.TypeOf(item.EQEmuItem{}).Field(index).Type.Kind() {
+	case reflect.Int64:
+		return kindInt
+	case reflect.Float64:
+		return kindFloat
+	}
+	return kindString
+}
+
+// exprFieldValue converts a struct field to an int64, float64 or string
+func exprFieldValue(field reflect.Value) interface{} {
+	switch field.Kind() {
+	case reflect.Int64:
+		return field.Int()
+	case reflect.Float64:
+		return field.Float()
+	case reflect.String:
+		return field.String()
+	}
+	if valuer, ok := field.Interface().(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err == nil {
+			switch v := val.(type) {
+			case string:
+				return v
+			case time.Time:
+				return v.Format(item.TimeFormat)
+			}
+		}
+	}
+	return ""
+}
+
+func logicalNode(left exprNode, right exprNode, isOr bool) exprNode {
+	return func(s reflect.Value) (interface{}, error) {
+		val, err := left(s)
+		if err != nil {
+			return nil, err
+		}
+		if exprTruth(val) == isOr {
+			return exprBool(isOr), nil
+		}
+		val, err = right(s)
+		if err != nil {
+			return nil, err
+		}
+		return exprBool(exprTruth(val)), nil
+	}
+}
+
+func binaryNode(op string, left exprNode, right exprNode) exprNode {
+	return func(s reflect.Value) (interface{}, error) {
+		a, err := left(s)
+		if err != nil {
+			return nil, err
+		}
+		b, err := right(s)
+		if err != nil {
+			return nil, err
+		}
+		return exprBinary(op, a, b)
+	}
+}
+
+func exprBinary(op string, a interface{}, b interface{}) (interface{}, error) {
+	as, aIsString := a.(string)
+	bs, bIsString := b.(string)
+	if aIsString || bIsString {
+		if !aIsString || !bIsString {
+			return nil, fmt.Errorf("cannot compare %v and %v with %s", a, b, op)
+		}
+		switch op {
+		case "==":
+			return exprBool(as == bs), nil
+		case "!=":
+			return exprBool(as != bs), nil
+		case "<":
+			return exprBool(as < bs), nil
+		case "<=":
+			return exprBool(as <= bs), nil
+		case ">":
+			return exprBool(as > bs), nil
+		case ">=":
+			return exprBool(as >= bs), nil
+		case "+":
+			return as + bs, nil
+		}
+		return nil, fmt.Errorf("operator %s is not supported on strings", op)
+	}
+
+	ai, aIsInt := a.(int64)
+	bi, bIsInt := b.(int64)
+	if aIsInt && bIsInt {
+		switch op {
+		case "&":
+			return ai & bi, nil
+		case "|":
+			return ai | bi, nil
+		case "^":
+			return ai ^ bi, nil
+		case "<<":
+			return ai << uint64(bi), nil
+		case ">>":
+			return ai >> uint64(bi), nil
+		case "%":
+			if bi == 0 {
+				return nil, fmt.Errorf("modulo by zero")
+			}
+			return ai % bi, nil
+		case "+":
+			return ai + bi, nil
+		case "-":
+			return ai - bi, nil
+		case "*":
+			return ai * bi, nil
+		case "/":
+			if bi == 0 {
+				return nil, fmt.Errorf("divide by zero")
+			}
+			return ai / bi, nil
+		}
+	}
+
+	af, bf := exprFloat(a), exprFloat(b)
+	switch op {
+	case "==":
+		return exprBool(af == bf), nil
+	case "!=":
+		return exprBool(af != bf), nil
+	case "<":
+		return exprBool(af < bf), nil
+	case "<=":
+		return exprBool(af <= bf), nil
+	case ">":
+		return exprBool(af > bf), nil
+	case ">=":
+		return exprBool(af >= bf), nil
+	case "+":
+		return af + bf, nil
+	case "-":
+		return af - bf, nil
+	case "*":
+		return af * bf, nil
+	case "/":
+		if bf == 0 {
+			return nil, fmt.Errorf("divide by zero")
+		}
+		return af / bf, nil
+	}
+	return nil, fmt.Errorf("operator %s requires integers", op)
+}
+
+func exprFloat(val interface{}) float64 {
+	switch v := val.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func exprBool(val bool) int64 {
+	if val {
+		return 1
+	}
+	return 0
+}
+
+// exprTruth returns false for zero and empty strings
+func exprTruth(val interface{}) bool {
+	switch v := val.(type) {
+	case int64:
+		return v != 0
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return false
+}
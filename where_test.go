@@ -0,0 +1,137 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xackery/eqitem/item"
+)
+
+func TestLexWhere(t *testing.T) {
+	tests := []struct {
+		expr  string
+		texts []string
+		err   string
+	}{
+		{expr: "reqlevel<=60", texts: []string{"reqlevel", "<=", "60"}},
+		{expr: "classes&8 && !nodrop", texts: []string{"classes", "&", "8", "&&", "!", "nodrop"}},
+		{expr: "a<<2>>1", texts: []string{"a", "<<", "2", ">>", "1"}},
+		{expr: `name=="Cloth Cap"`, texts: []string{"name", "==", "Cloth Cap"}},
+		{expr: "name=='it''s'", texts: []string{"name", "==", "it", "s"}},
+		{expr: "price >= 1.5", texts: []string{"price", ">=", "1.5"}},
+		{expr: `name=="open`, err: "unterminated string at position 6"},
+		{expr: "id # 1", err: "unexpected '#' at position 3"},
+	}
+	for _, tt := range tests {
+		tokens, err := lexWhere(tt.expr)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s: got error %v, want %s", tt.expr, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		texts := []string{}
+		for _, token := range tokens {
+			texts = append(texts, token.text)
+		}
+		if strings.Join(texts, " ") != strings.Join(tt.texts, " ") {
+			t.Errorf("%s: got %q, want %q", tt.expr, texts, tt.texts)
+		}
+	}
+}
+
+func TestWhereMatch(t *testing.T) {
+	tests := []struct {
+		expr  string
+		item  item.EQEmuItem
+		match bool
+	}{
+		//& binds tighter than ==, as in go
+		{expr: "classes&8==8", item: item.EQEmuItem{Classes: 8}, match: true},
+		{expr: "classes&8==8", item: item.EQEmuItem{Classes: 7}, match: false},
+		{expr: "1+2*3==7", match: true},
+		{expr: "(1+2)*3==9", match: true},
+		{expr: "2+3<<1==8", match: true},
+		{expr: "10-4-3==3", match: true},
+		{expr: "1|2^3==0", match: true},
+		{expr: "id==1 || id==2 && reqlevel>50", item: item.EQEmuItem{ID: 1}, match: true},
+		{expr: "(id==1 || id==2) && reqlevel>50", item: item.EQEmuItem{ID: 1}, match: false},
+		{expr: "!id", match: true},
+		{expr: "-reqlevel < 0", item: item.EQEmuItem{Reqlevel: 5}, match: true},
+		{expr: "sellrate > 1.5", item: item.EQEmuItem{Sellrate: 2}, match: true},
+		{expr: "7/2==3", match: true},
+		{expr: "7/2.0==3.5", match: true},
+		{expr: `NAME=="Cloth Cap"`, item: item.EQEmuItem{Name: "Cloth Cap"}, match: true},
+		{expr: `name+"s"=="caps"`, item: item.EQEmuItem{Name: "cap"}, match: true},
+		{expr: `name<"b"`, item: item.EQEmuItem{Name: "a"}, match: true},
+		//the right side is not evaluated when the left side decides the result
+		{expr: "id==1 || 1/0==0", item: item.EQEmuItem{ID: 1}, match: true},
+		{expr: "id!=1 && 1/0==0", item: item.EQEmuItem{ID: 1}, match: false},
+	}
+	for _, tt := range tests {
+		where, err := parseWhere(tt.expr)
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		it := tt.item
+		match, err := where.match(&it)
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		if match != tt.match {
+			t.Errorf("%s: got %t, want %t", tt.expr, match, tt.match)
+		}
+	}
+}
+
+func TestWhereMatchError(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{expr: "id==2 || 1/0==0", err: "divide by zero"},
+		{expr: "id%(id-1)==0", err: "modulo by zero"},
+	}
+	for _, tt := range tests {
+		where, err := parseWhere(tt.expr)
+		if err != nil {
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		_, err = where.match(&item.EQEmuItem{ID: 1})
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%s: got error %v, want %s", tt.expr, err, tt.err)
+		}
+	}
+}
+
+func TestParseWhereError(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{expr: "name == 5", err: "position 5: cannot use == on string and integer"},
+		{expr: "5 < name", err: "position 2: cannot use < on integer and string"},
+		{expr: "name - name", err: "position 5: operator - is not supported on strings"},
+		{expr: "-name", err: "position 0: cannot negate a string"},
+		{expr: "sellrate & 1", err: "position 9: operator & requires integers"},
+		{expr: "id % 1.5", err: "position 3: operator % requires integers"},
+		{expr: "(id == 1 || name == 2)", err: "position 17: cannot use == on string and integer"},
+		{expr: "nosuchfield > 1", err: "unknown field nosuchfield at position 0"},
+		{expr: "id ==", err: "unexpected end of expression"},
+		{expr: "(id == 1", err: "missing )"},
+		{expr: "id == 1 2", err: "unexpected 2 at position 8"},
+	}
+	for _, tt := range tests {
+		_, err := parseWhere(tt.expr)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("%s: got error %v, want %s", tt.expr, err, tt.err)
+		}
+	}
+}